@@ -9,37 +9,47 @@ import (
 )
 
 type AIClient struct {
-	client   *openai.Client
-	provider string
-	model    string
+	client      *openai.Client
+	provider    string
+	model       string
+	temperature float32
+	maxTokens   int
+	thresholds  ThresholdsConfig
 }
 
 type AIInsights struct {
-	Summary                 string
-	EnhancedRecommendations []string
-	RiskAssessment          string
-	AutomationSuggestions   []string
+	Summary                 string   `json:"summary"`
+	EnhancedRecommendations []string `json:"enhancedRecommendations"`
+	RiskAssessment          string   `json:"riskAssessment"`
+	AutomationSuggestions   []string `json:"automationSuggestions"`
 }
 
-func NewAIClient(apiKey, provider, endpoint, model string) (*AIClient, error) {
+func NewAIClient(apiKey string, cfg *Config) (*AIClient, error) {
 	var client *openai.Client
 
-	if provider == "azure" && endpoint != "" {
-		config := openai.DefaultAzureConfig(apiKey, endpoint)
+	if cfg.AI.Provider == "azure" {
+		if cfg.AI.Endpoint == "" {
+			return nil, fmt.Errorf("azure provider requires an AI endpoint")
+		}
+		config := openai.DefaultAzureConfig(apiKey, cfg.AI.Endpoint)
 		client = openai.NewClientWithConfig(config)
 	} else {
 		client = openai.NewClient(apiKey)
 	}
 
 	// Default to gpt-4o if no model specified
+	model := cfg.AI.Model
 	if model == "" {
 		model = "gpt-4o"
 	}
 
 	return &AIClient{
-		client:   client,
-		provider: provider,
-		model:    model,
+		client:      client,
+		provider:    cfg.AI.Provider,
+		model:       model,
+		temperature: cfg.AI.Temperature,
+		maxTokens:   cfg.AI.MaxTokens,
+		thresholds:  cfg.Thresholds,
 	}, nil
 }
 
@@ -62,8 +72,8 @@ func (ai *AIClient) AnalyzeCluster(ctx context.Context, data *ClusterData, analy
 					Content: prompt,
 				},
 			},
-			Temperature: 0.7,
-			MaxCompletionTokens:   2000,
+			Temperature:         ai.temperature,
+			MaxCompletionTokens: ai.maxTokens,
 		},
 	)
 
@@ -88,8 +98,12 @@ func (ai *AIClient) buildAnalysisPrompt(data *ClusterData, analysis *Analysis) s
 	sb.WriteString(fmt.Sprintf("## Cluster Overview\n"))
 	sb.WriteString(fmt.Sprintf("- Total Pods: %d\n", len(data.Pods)))
 	sb.WriteString(fmt.Sprintf("- Total Nodes: %d\n", len(data.Nodes)))
-	sb.WriteString(fmt.Sprintf("- Health Status: %s\n", analysis.ClusterHealth))
+	sb.WriteString(fmt.Sprintf("- Health Status: %s\n", analysis.Health))
 	sb.WriteString(fmt.Sprintf("- OOM Events: %d\n", len(analysis.OOMEvents)))
+	sb.WriteString(fmt.Sprintf("- Findings: %d (%s)\n", len(analysis.Findings), severitySummary(analysis.Findings)))
+	if b := analysis.Baseline; b != nil {
+		sb.WriteString(fmt.Sprintf("- Since Baseline: %d new, %d persisting, %d resolved\n", len(b.New), b.Persisting, len(b.Resolved)))
+	}
 	sb.WriteString(fmt.Sprintf("- Pods Missing Resources: %d\n\n", len(analysis.ResourceGaps)))
 
 	sb.WriteString("## Critical Issues Detected\n")
@@ -100,6 +114,59 @@ func (ai *AIClient) buildAnalysisPrompt(data *ClusterData, analysis *Analysis) s
 	}
 	sb.WriteString("\n")
 
+	if len(analysis.Findings) > 0 {
+		sb.WriteString("## Findings by Rule\n")
+		for _, count := range countByRule(analysis.Findings) {
+			sb.WriteString(fmt.Sprintf("- %s (%s): %d - %s\n", count.Rule.ID, count.Rule.Severity, count.Findings, count.Rule.Title))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(analysis.NodeConditions) > 0 {
+		sb.WriteString("## Node Conditions\n")
+		for _, c := range analysis.NodeConditions {
+			sb.WriteString(fmt.Sprintf("- %s: %s (%s) %s\n", c.NodeName, c.Problem, c.Reason, c.Message))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(analysis.NodePools) > 0 {
+		sb.WriteString("## Node Pools\n")
+		for _, pool := range analysis.NodePools {
+			sb.WriteString(fmt.Sprintf("- %s: %d nodes, CPU %.1f%% requested (nodes %.1f-%.1f%%, stddev %.1f), memory %.1f%% requested (nodes %.1f-%.1f%%, stddev %.1f)\n",
+				pool.Pool, pool.Nodes,
+				pool.CPUPercent, pool.CPUSpread.Min, pool.CPUSpread.Max, pool.CPUSpread.StdDev,
+				pool.MemoryPercent, pool.MemorySpread.Min, pool.MemorySpread.Max, pool.MemorySpread.StdDev))
+			for _, outlier := range pool.Outliers {
+				sb.WriteString(fmt.Sprintf("  - Outlier: %s\n", outlier))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(analysis.NodeUtilization) > 0 {
+		sb.WriteString("## Node Utilization (actual usage vs requests)\n")
+		for _, u := range analysis.NodeUtilization {
+			sb.WriteString(fmt.Sprintf("- %s: CPU %.1f%% used (%.1f%% of requests, limits %.2fx allocatable), memory %.1f%% used (%.1f%% of requests, limits %.2fx allocatable)",
+				u.NodeName, u.CPUUsagePercent, u.CPUUsageOfRequests, u.CPUOvercommit,
+				u.MemoryUsagePercent, u.MemoryUsageOfRequests, u.MemoryOvercommit))
+			if u.Assessment != "" {
+				sb.WriteString(" - " + u.Assessment)
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	if nodes := atRiskNodes(analysis.NodeOOMRisk); len(nodes) > 0 {
+		sb.WriteString("## Node OOM Risk (memory limits vs allocatable)\n")
+		for _, r := range nodes {
+			sb.WriteString(fmt.Sprintf("- %s: %s risk, limits %.2fx allocatable, %d containers without a memory limit, %.1f%% memory in use (%s), %d OOM kills\n",
+				r.NodeName, r.Risk, r.MemoryOvercommit, r.UnboundedContainers, r.MemoryPercent, r.MemorySource, r.OOMKills))
+		}
+		sb.WriteString("\n")
+	}
+
 	sb.WriteString("## Namespace Risk Analysis\n")
 	for _, ns := range analysis.NamespaceAnalysis {
 		sb.WriteString(fmt.Sprintf("- %s: %s risk (%d/%d pods missing resources)\n",
@@ -113,7 +180,7 @@ func (ai *AIClient) buildAnalysisPrompt(data *ClusterData, analysis *Analysis) s
 	sb.WriteString(fmt.Sprintf("- Has Resource Limits: %v\n\n", analysis.RabbitMQFindings.HasResourceLimits))
 
 	sb.WriteString("## Short-Lived Jobs\n")
-	sb.WriteString(fmt.Sprintf("- Short Jobs (<2min): %d\n", analysis.ShortLivedJobs.ShortJobs))
+	sb.WriteString(fmt.Sprintf("- Short Jobs (<%gmin): %d\n", ai.thresholds.ShortJobDuration, analysis.ShortLivedJobs.ShortJobs))
 	sb.WriteString(fmt.Sprintf("- Total Jobs: %d\n\n", analysis.ShortLivedJobs.TotalJobs))
 
 	sb.WriteString("Please provide:\n")
@@ -165,7 +232,7 @@ func getSystemPrompt() string {
    - Include priority class and resource allocation strategies
 
 6. **Namespace Analysis**:
-   - Analyze each application namespace (typically 3-letter codes like asu, cgh, etc.)
+   - Analyze each application namespace listed under Namespace Risk Analysis
    - For each namespace, identify which pods are missing resource requests/limits
    - Prioritize which pods in each namespace most critically need resource constraints
    - Provide namespace-specific recommendations with suggested resource values based on observed usage patterns
@@ -193,28 +260,29 @@ func (ai *AIClient) SuggestResourceLimits(ctx context.Context, pods []PodResourc
 
 	// Build prompt
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Analyze the following pods in namespace '%s' and suggest appropriate CPU and Memory requests/limits.\n\n", namespace))
-	sb.WriteString("Pods with missing resource configurations:\n\n")
+	sb.WriteString(fmt.Sprintf("Analyze the following workloads in namespace '%s' and suggest appropriate CPU and Memory requests/limits per replica.\n\n", namespace))
+	sb.WriteString("Workload containers with missing resource configurations:\n\n")
 
 	for _, pod := range missingResourcePods {
-		sb.WriteString(fmt.Sprintf("**Pod: %s, Container: %s**\n", pod.PodName, pod.ContainerName))
+		sb.WriteString(fmt.Sprintf("**Workload: %s (%s), Container: %s**\n", pod.Workload, pluralize(pod.Replicas, "replica"), containerLabel(pod.ContainerName, pod.ContainerType)))
 		sb.WriteString(fmt.Sprintf("- CPU Request: %s\n", pod.CPURequest))
 		sb.WriteString(fmt.Sprintf("- CPU Limit: %s\n", pod.CPULimit))
 		sb.WriteString(fmt.Sprintf("- Memory Request: %s\n", pod.MemoryRequest))
 		sb.WriteString(fmt.Sprintf("- Memory Limit: %s\n", pod.MemoryLimit))
 		if pod.CurrentCPU != "N/A" {
-			sb.WriteString(fmt.Sprintf("- Current CPU Usage: %s\n", pod.CurrentCPU))
+			sb.WriteString(fmt.Sprintf("- Current CPU Usage (busiest replica): %s\n", pod.CurrentCPU))
 		}
 		if pod.CurrentMemory != "N/A" {
-			sb.WriteString(fmt.Sprintf("- Current Memory Usage: %s\n", pod.CurrentMemory))
+			sb.WriteString(fmt.Sprintf("- Current Memory Usage (busiest replica): %s\n", pod.CurrentMemory))
 		}
 		sb.WriteString("\n")
 	}
 
 	sb.WriteString("\nProvide suggested values for ONLY the missing fields. Format your response as:\n")
-	sb.WriteString("POD_NAME|CONTAINER_NAME|CPU_REQUEST|CPU_LIMIT|MEMORY_REQUEST|MEMORY_LIMIT\n")
+	sb.WriteString("WORKLOAD|CONTAINER_NAME|CPU_REQUEST|CPU_LIMIT|MEMORY_REQUEST|MEMORY_LIMIT\n")
 	sb.WriteString("\nUse 'KEEP' for values that are already set. Base suggestions on current usage if available, or provide reasonable defaults for the workload type.\n")
-	sb.WriteString("For example: my-pod|app|100m|200m|256Mi|512Mi\n")
+	sb.WriteString("WORKLOAD is exactly as given above, e.g. Deployment/my-app|app|100m|200m|256Mi|512Mi\n")
+	sb.WriteString("Containers marked (init) run to completion before the others start; (sidecar) containers run for the whole life of the pod. Give CONTAINER_NAME without the marker.\n")
 
 	// Call AI
 	resp, err := ai.client.CreateChatCompletion(
@@ -231,8 +299,8 @@ func (ai *AIClient) SuggestResourceLimits(ctx context.Context, pods []PodResourc
 					Content: sb.String(),
 				},
 			},
-			Temperature: 0.3,
-			MaxCompletionTokens:   1500,
+			Temperature:         0.3,
+			MaxCompletionTokens: 1500,
 		},
 	)
 
@@ -244,18 +312,27 @@ func (ai *AIClient) SuggestResourceLimits(ctx context.Context, pods []PodResourc
 		return nil, fmt.Errorf("no response from OpenAI")
 	}
 
-	// Parse response
-	suggestions := parseResourceSuggestions(resp.Choices[0].Message.Content)
+	// Parse response, keeping only suggestions for the workloads asked about
+	parsed := parseResourceSuggestions(resp.Choices[0].Message.Content)
+	suggestions := make(map[string]ResourceSuggestion)
+	for _, pod := range missingResourcePods {
+		key := suggestionKey(pod.Workload, pod.ContainerName)
+		if s, ok := parsed[key]; ok {
+			s.PodName = pod.PodName
+			suggestions[key] = s
+		}
+	}
 	return suggestions, nil
 }
 
 type ResourceSuggestion struct {
-	PodName       string
-	ContainerName string
-	CPURequest    string
-	CPULimit      string
-	MemoryRequest string
-	MemoryLimit   string
+	Workload      string `json:"workload"` // Kind/name
+	PodName       string `json:"podName"`  // one of the workload's pods
+	ContainerName string `json:"containerName"`
+	CPURequest    string `json:"cpuRequest"`
+	CPULimit      string `json:"cpuLimit"`
+	MemoryRequest string `json:"memoryRequest"`
+	MemoryLimit   string `json:"memoryLimit"`
 }
 
 func parseResourceSuggestions(response string) map[string]ResourceSuggestion {
@@ -270,10 +347,11 @@ func parseResourceSuggestions(response string) map[string]ResourceSuggestion {
 
 		parts := strings.Split(line, "|")
 		if len(parts) >= 6 {
-			key := parts[0] + "/" + parts[1]
-			suggestions[key] = ResourceSuggestion{
-				PodName:       strings.TrimSpace(parts[0]),
-				ContainerName: strings.TrimSpace(parts[1]),
+			workload := strings.Trim(strings.TrimSpace(parts[0]), "`")
+			container, _, _ := strings.Cut(strings.TrimSpace(parts[1]), " (")
+			suggestions[workload+"/"+container] = ResourceSuggestion{
+				Workload:      workload,
+				ContainerName: container,
 				CPURequest:    strings.TrimSpace(parts[2]),
 				CPULimit:      strings.TrimSpace(parts[3]),
 				MemoryRequest: strings.TrimSpace(parts[4]),
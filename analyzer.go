@@ -4,10 +4,15 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -18,123 +23,206 @@ import (
 type Analyzer struct {
 	clientset     *kubernetes.Clientset
 	dynamicClient dynamic.Interface
+	config        *Config
 }
 
 type PodMetrics struct {
-	Containers map[string]ContainerMetrics // containerName -> metrics
+	Containers map[string]ContainerMetrics `json:"containers"` // containerName -> metrics
 }
 
 type ContainerMetrics struct {
-	CPUUsage    string
-	MemoryUsage string
+	CPUUsage    string `json:"cpuUsage"`
+	MemoryUsage string `json:"memoryUsage"`
+}
+
+// NodeMetrics is the actual usage of a node as reported by metrics-server.
+type NodeMetrics struct {
+	CPUUsage    string `json:"cpuUsage"`
+	MemoryUsage string `json:"memoryUsage"`
 }
 
 type ClusterData struct {
-	ClusterName   string
-	Pods          []corev1.Pod
-	Nodes         []corev1.Node
-	Events        []corev1.Event
-	Namespaces    []corev1.Namespace
-	VeleroBackups []unstructured.Unstructured
-	PodMetrics    map[string]PodMetrics                    // namespace/podname -> metrics
-	AISuggestions map[string]map[string]ResourceSuggestion // namespace -> pod/container -> suggestion
+	ClusterName      string                                   `json:"clusterName"`
+	CollectedAt      time.Time                                `json:"collectedAt"`     // reference time for 24h/48h/7d windows
+	Scope            []string                                 `json:"scope,omitempty"` // namespaces collected in namespace-scoped mode; empty for cluster-wide
+	Pods             []corev1.Pod                             `json:"pods"`
+	Nodes            []corev1.Node                            `json:"nodes"`
+	Events           []corev1.Event                           `json:"events"`
+	Namespaces       []corev1.Namespace                       `json:"namespaces"`
+	VeleroBackups    []unstructured.Unstructured              `json:"veleroBackups"`
+	ReplicaSets      []appsv1.ReplicaSet                      `json:"replicaSets,omitempty"`     // metadata only, to resolve pod owners
+	Jobs             []batchv1.Job                            `json:"jobs,omitempty"`            // metadata only, to resolve pod owners
+	CustomResources  map[string][]unstructured.Unstructured   `json:"customResources,omitempty"` // source -> objects, for checks that require them
+	PodMetrics       map[string]PodMetrics                    `json:"podMetrics"`                // namespace/podname -> metrics
+	NodeMetrics      map[string]NodeMetrics                   `json:"nodeMetrics,omitempty"`     // node name -> metrics
+	CollectionErrors []CollectionError                        `json:"collectionErrors,omitempty"`
+	AISuggestions    map[string]map[string]ResourceSuggestion `json:"-"` // namespace -> Kind/name/container -> suggestion
 }
 
+// ResourceGap is a workload container missing requests or limits, merged across its replicas.
 type ResourceGap struct {
-	Namespace       string
-	PodName         string
-	Container       string
-	MissingRequests bool
-	MissingLimits   bool
+	Namespace       string   `json:"namespace"`
+	Workload        Workload `json:"workload"`
+	Replicas        int      `json:"replicas"` // pods of the workload with the gap
+	PodName         string   `json:"podName"`  // one of the affected pods
+	Container       string   `json:"container"`
+	ContainerType   string   `json:"containerType"` // container, init or sidecar
+	MissingRequests bool     `json:"missingRequests"`
+	MissingLimits   bool     `json:"missingLimits"`
 }
 
 type NodeIssue struct {
-	NodeName          string
-	Issue             string
-	RequestedCPU      float64
-	RequestedMemory   float64
-	AllocatableCPU    float64
-	AllocatableMemory float64
+	NodeName          string           `json:"nodeName"`
+	Issue             string           `json:"issue"`
+	RequestedCPU      float64          `json:"requestedCPUCores"`
+	RequestedMemory   float64          `json:"requestedMemoryGiB"`
+	AllocatableCPU    float64          `json:"allocatableCPUCores"`
+	AllocatableMemory float64          `json:"allocatableMemoryGiB"`
+	CPUPercent        float64          `json:"cpuPercent"`
+	MemoryPercent     float64          `json:"memoryPercent"`
+	PodCount          int              `json:"podCount"`
+	TopPods           []NodePodRequest `json:"topPods"` // largest requests of the resource in Issue
+}
+
+// NodePodRequest is a pod's effective requests on a node.
+type NodePodRequest struct {
+	Namespace       string  `json:"namespace"`
+	PodName         string  `json:"podName"`
+	RequestedCPU    float64 `json:"requestedCPUCores"`
+	RequestedMemory float64 `json:"requestedMemoryGiB"`
 }
 
 type NamespaceAnalysis struct {
-	Namespace           string
-	TotalPods           int
-	PodsWithoutRequests int
-	PodsWithoutLimits   int
-	RiskLevel           string
-	CriticalPods        []string
-	Recommendations     []string
+	Namespace           string   `json:"namespace"`
+	TotalPods           int      `json:"totalPods"`
+	PodsWithoutRequests int      `json:"podsWithoutRequests"`
+	PodsWithoutLimits   int      `json:"podsWithoutLimits"`
+	RiskLevel           string   `json:"riskLevel"`
+	CriticalPods        []string `json:"criticalPods"`
+	CriticalWorkloads   []string `json:"criticalWorkloads"` // "Kind/name (N pods)" for pods without requests
+	Recommendations     []string `json:"recommendations"`
 }
 
 type Analysis struct {
-	ClusterHealth     string
-	CriticalIssues    []CriticalIssue
-	ResourceGaps      []ResourceGap
-	NodeIssues        []NodeIssue
-	OOMEvents         []OOMEvent
-	NamespaceAnalysis []NamespaceAnalysis
-	RabbitMQFindings  RabbitMQAnalysis
-	ShortLivedJobs    JobAnalysis
-	PodRestarts       PodRestartAnalysis
-	FluxEvents        FluxEventAnalysis
-	NonFluxEvents     NonFluxEventAnalysis
-	VeleroBackups     VeleroBackupAnalysis
-	AIInsights        *AIInsights
+	Baseline           *BaselineComparison  `json:"baseline,omitempty"` // new and resolved findings against -baseline
+	Health             HealthScore          `json:"health"`
+	CriticalIssues     []CriticalIssue      `json:"criticalIssues"`
+	Findings           []Finding            `json:"findings"`                     // every problem found, most severe first
+	SuppressedFindings []SuppressedFinding  `json:"suppressedFindings,omitempty"` // findings accepted by a waiver
+	ExpiredWaivers     []Waiver             `json:"expiredWaivers,omitempty"`     // waivers file entries past their expiry date
+	ResourceGaps       []ResourceGap        `json:"resourceGaps"`
+	NodeIssues         []NodeIssue          `json:"nodeIssues"`
+	NodePools          []NodePoolAnalysis   `json:"nodePools"`
+	NodeUtilization    []NodeUtilization    `json:"nodeUtilization"`
+	NodeConditions     []NodeConditionIssue `json:"nodeConditions"`
+	OOMEvents          []OOMEvent           `json:"oomEvents"`
+	NodeOOMRisk        []NodeOOMRisk        `json:"nodeOOMRisk"`
+	NamespaceAnalysis  []NamespaceAnalysis  `json:"namespaceAnalysis"`
+	ExcludedNamespaces []ExcludedNamespace  `json:"excludedNamespaces"`
+	RabbitMQFindings   RabbitMQAnalysis     `json:"rabbitMQFindings"`
+	ShortLivedJobs     JobAnalysis          `json:"shortLivedJobs"`
+	PodRestarts        PodRestartAnalysis   `json:"podRestarts"`
+	FluxEvents         FluxEventAnalysis    `json:"fluxEvents"`
+	NonFluxEvents      NonFluxEventAnalysis `json:"nonFluxEvents"`
+	VeleroBackups      VeleroBackupAnalysis `json:"veleroBackups"`
+	SkippedAnalyses    []SkippedAnalysis    `json:"skippedAnalyses,omitempty"` // analyses whose input data could not be collected
+	DisabledChecks     []string             `json:"disabledChecks,omitempty"`  // IDs of the checks the configuration turned off
+	AIInsights         *AIInsights          `json:"aiInsights,omitempty"`
 }
 
 type CriticalIssue struct {
-	Priority       int
-	Title          string
-	Description    string
-	Impact         string
-	Recommendation string
-	Examples       []string
+	RuleID         string   `json:"ruleId"` // rule of the findings the issue summarizes
+	Priority       int      `json:"priority"`
+	Title          string   `json:"title"`
+	Description    string   `json:"description"`
+	Impact         string   `json:"impact"`
+	Recommendation string   `json:"recommendation"`
+	Examples       []string `json:"examples"`
 }
 
+// OOMEvent is an OOM kill, found in an event, in the last termination state
+// of a container, or both.
 type OOMEvent struct {
-	NodeName  string
-	PodName   string
-	Namespace string
-	Container string
-	Timestamp time.Time
-	Reason    string
+	NodeName    string    `json:"nodeName"`
+	PodName     string    `json:"podName"`
+	Namespace   string    `json:"namespace"`
+	Workload    Workload  `json:"workload"` // owning workload of the pod
+	Container   string    `json:"container"`
+	Timestamp   time.Time `json:"timestamp"`
+	Reason      string    `json:"reason"`
+	Source      string    `json:"source"`                // "event", "containerStatus" or "both"
+	ExitCode    int32     `json:"exitCode,omitempty"`    // from the container status; 137 for a kernel OOM kill
+	MemoryLimit string    `json:"memoryLimit,omitempty"` // memory limit of the container, if set
+	MemoryUsage string    `json:"memoryUsage,omitempty"` // last metrics-server sample of the container, if collected
 }
 
+// Sources of OOM kills.
+const (
+	oomSourceEvent  = "event"
+	oomSourceStatus = "containerStatus"
+	oomSourceBoth   = "both"
+)
+
 type RabbitMQAnalysis struct {
-	RabbitMQPods      []string
-	HasPriorityClass  bool
-	HasResourceLimits bool
-	Recommendations   []string
+	RabbitMQPods      []string           `json:"rabbitMQPods"`
+	Workloads         []RabbitMQWorkload `json:"workloads"`
+	HasPriorityClass  bool               `json:"hasPriorityClass"`  // any RabbitMQ pod has one
+	HasResourceLimits bool               `json:"hasResourceLimits"` // any RabbitMQ pod has a memory limit
+	Recommendations   []string           `json:"recommendations"`
+}
+
+// RabbitMQWorkload is a workload running RabbitMQ, usually a StatefulSet.
+type RabbitMQWorkload struct {
+	Namespace        string   `json:"namespace"`
+	Workload         Workload `json:"workload"`
+	Pods             int      `json:"pods"`
+	HasPriorityClass bool     `json:"hasPriorityClass"`
+	HasMemoryLimit   bool     `json:"hasMemoryLimit"`
 }
 
 type JobAnalysis struct {
-	ShortJobs         int
-	TotalJobs         int
-	ImpactOnStability string
-	Recommendations   []string
+	ShortJobs         int             `json:"shortJobs"`
+	TotalJobs         int             `json:"totalJobs"`
+	Workloads         []ShortLivedJob `json:"workloads"` // Jobs and CronJobs with short-lived pods, most first
+	ImpactOnStability string          `json:"impactOnStability"`
+	Recommendations   []string        `json:"recommendations"`
 }
 
+// ShortLivedJob counts the job pods of a Job, or of the CronJob that created it.
+type ShortLivedJob struct {
+	Namespace string   `json:"namespace"`
+	Workload  Workload `json:"workload"`
+	ShortJobs int      `json:"shortJobs"`
+	TotalJobs int      `json:"totalJobs"`
+}
+
+// PodRestart is a restarting workload container, merged across its replicas.
+// Restart counts are summed; the pod, time and reason are of the latest restart.
 type PodRestart struct {
-	Namespace       string
-	PodName         string
-	ContainerName   string
-	RestartCount    int32
-	LastRestartTime time.Time
-	Reason          string
+	Namespace       string    `json:"namespace"`
+	Workload        Workload  `json:"workload"`
+	Pods            int       `json:"pods"` // pods of the workload with restarts in the window
+	PodName         string    `json:"podName"`
+	ContainerName   string    `json:"containerName"`
+	RestartCount    int32     `json:"restartCount"`
+	LastRestartTime time.Time `json:"lastRestartTime"`
+	Reason          string    `json:"reason"`
 }
 
 type PodRestartAnalysis struct {
-	Last24Hours  []PodRestart
-	Last7Days    []PodRestart
-	TotalPods24h int
-	TotalPods7d  int
+	Last24Hours  []PodRestart `json:"last24Hours"`
+	Last7Days    []PodRestart `json:"last7Days"`
+	TotalPods24h int          `json:"totalPods24h"`
+	TotalPods7d  int          `json:"totalPods7d"`
 }
 
 type PodResourceInfo struct {
 	Namespace     string
+	Workload      Workload
+	Replicas      int // running pods of the workload, when merged per workload
 	PodName       string
 	ContainerName string
+	ContainerType string
 	Status        string
 	CPURequest    string
 	CPULimit      string
@@ -145,169 +233,347 @@ type PodResourceInfo struct {
 }
 
 type EventInfo struct {
-	Type           string
-	Reason         string
-	Message        string
-	Namespace      string
-	InvolvedObject string
-	Count          int32
-	FirstTime      time.Time
-	LastTime       time.Time
+	Type           string    `json:"type"`
+	Reason         string    `json:"reason"`
+	Message        string    `json:"message"`
+	Namespace      string    `json:"namespace"`
+	InvolvedObject string    `json:"involvedObject"`
+	Count          int32     `json:"count"`
+	FirstTime      time.Time `json:"firstTime"`
+	LastTime       time.Time `json:"lastTime"`
 }
 
 type FluxEventAnalysis struct {
-	Last24Hours []EventInfo
-	Last48Hours []EventInfo
-	Warnings24h int
-	Warnings48h int
-	Errors24h   int
-	Errors48h   int
+	Last24Hours []EventInfo `json:"last24Hours"`
+	Last48Hours []EventInfo `json:"last48Hours"`
+	Warnings24h int         `json:"warnings24h"`
+	Warnings48h int         `json:"warnings48h"`
+	Errors24h   int         `json:"errors24h"`
+	Errors48h   int         `json:"errors48h"`
 }
 
 type NonFluxEventAnalysis struct {
-	Last24Hours []EventInfo
-	Last48Hours []EventInfo
-	Warnings24h int
-	Warnings48h int
+	Last24Hours []EventInfo `json:"last24Hours"`
+	Last48Hours []EventInfo `json:"last48Hours"`
+	Warnings24h int         `json:"warnings24h"`
+	Warnings48h int         `json:"warnings48h"`
 }
 
 type VeleroBackup struct {
-	Name           string
-	Namespace      string
-	Status         string
-	StartTime      time.Time
-	CompletionTime time.Time
-	Duration       time.Duration
-	Errors         int
-	Warnings       int
+	Name           string        `json:"name"`
+	Namespace      string        `json:"namespace"`
+	Status         string        `json:"status"`
+	StartTime      time.Time     `json:"startTime"`
+	CompletionTime time.Time     `json:"completionTime"`
+	Duration       time.Duration `json:"duration"` // nanoseconds
+	Errors         int           `json:"errors"`
+	Warnings       int           `json:"warnings"`
 }
 
 type VeleroBackupAnalysis struct {
-	Last24Hours      []VeleroBackup
-	Last48Hours      []VeleroBackup
-	TotalBackups24h  int
-	TotalBackups48h  int
-	FailedBackups24h int
-	FailedBackups48h int
+	Last24Hours      []VeleroBackup `json:"last24Hours"`
+	Last48Hours      []VeleroBackup `json:"last48Hours"`
+	TotalBackups24h  int            `json:"totalBackups24h"`
+	TotalBackups48h  int            `json:"totalBackups48h"`
+	FailedBackups24h int            `json:"failedBackups24h"`
+	FailedBackups48h int            `json:"failedBackups48h"`
 }
 
-func NewAnalyzer(clientset *kubernetes.Clientset, dynamicClient dynamic.Interface) *Analyzer {
+func NewAnalyzer(clientset *kubernetes.Clientset, dynamicClient dynamic.Interface, config *Config) *Analyzer {
+	if config == nil {
+		config = DefaultConfig()
+	}
 	return &Analyzer{
 		clientset:     clientset,
 		dynamicClient: dynamicClient,
+		config:        config,
 	}
 }
 
+// CollectClusterData lists pods, nodes, events, namespaces, pod and node
+// metrics and Velero backups concurrently, in pages. Each source is preflighted with a
+// SelfSubjectAccessReview so missing RBAC is reported as such rather than as
+// an opaque List failure. A source that fails is recorded in
+// CollectionErrors and the rest are still collected; an error is only returned
+// when none of the core sources could be listed.
+//
+// When the configuration is scoped to namespaces, only namespaced List calls
+// are made in those namespaces and nodes are not collected.
 func (a *Analyzer) CollectClusterData(ctx context.Context) (*ClusterData, error) {
 	data := &ClusterData{
-		PodMetrics: make(map[string]PodMetrics),
+		CollectedAt: time.Now(),
+		PodMetrics:  make(map[string]PodMetrics),
+		NodeMetrics: make(map[string]NodeMetrics),
 	}
+	pageSize := a.config.Kubernetes.PageSize
+	scope := a.config.Kubernetes.Namespaces()
+	data.Scope = scope
 
-	// Get cluster name from kubeconfig context or server
-	data.ClusterName = a.getClusterName(ctx)
+	var c collector
 
-	// Get all pods
-	pods, err := a.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("error listing pods: %w", err)
-	}
-	data.Pods = pods.Items
+	// Get cluster name from kubeconfig context or server
+	c.run("cluster-name", func() error {
+		data.ClusterName = a.getClusterName(ctx)
+		return nil
+	})
 
-	// Try to get pod metrics (best effort - metrics-server might not be available)
-	a.collectPodMetrics(ctx, data)
+	c.run(sourcePods, func() error {
+		pods, err := listScoped(ctx, a, "", "pods", func(ns string) func(context.Context, metav1.ListOptions) ([]corev1.Pod, string, error) {
+			return func(ctx context.Context, opts metav1.ListOptions) ([]corev1.Pod, string, error) {
+				list, err := a.clientset.CoreV1().Pods(ns).List(ctx, opts)
+				if err != nil {
+					return nil, "", err
+				}
+				for i := range list.Items {
+					list.Items[i].ManagedFields = nil
+				}
+				return list.Items, list.Continue, nil
+			}
+		})
+		data.Pods = pods
+		return err
+	})
 
-	// Get all nodes
-	nodes, err := a.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("error listing nodes: %w", err)
-	}
-	data.Nodes = nodes.Items
+	if len(scope) > 0 {
+		c.skip(sourceNodes, reasonOutOfScope, "nodes are cluster-scoped and not collected when the analysis is limited to namespaces")
+	} else {
+		c.run(sourceNodes, func() error {
+			if err := a.canList(ctx, "", "", "nodes"); err != nil {
+				return err
+			}
 
-	// Get recent events (last 24 hours)
-	events, err := a.clientset.CoreV1().Events("").List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("error listing events: %w", err)
+			nodes, err := listPaged(ctx, pageSize, func(ctx context.Context, opts metav1.ListOptions) ([]corev1.Node, string, error) {
+				list, err := a.clientset.CoreV1().Nodes().List(ctx, opts)
+				if err != nil {
+					return nil, "", err
+				}
+				for i := range list.Items {
+					list.Items[i].ManagedFields = nil
+				}
+				return list.Items, list.Continue, nil
+			})
+			data.Nodes = nodes
+			return err
+		})
 	}
-	data.Events = events.Items
 
-	// Get namespaces
-	namespaces, err := a.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("error listing namespaces: %w", err)
-	}
-	data.Namespaces = namespaces.Items
+	c.run(sourceEvents, func() error {
+		events, err := a.collectEvents(ctx)
+		data.Events = events
+		return err
+	})
 
-	// Get Velero backups if available
-	if a.dynamicClient != nil {
-		veleroGVR := schema.GroupVersionResource{
-			Group:    "velero.io",
-			Version:  "v1",
-			Resource: "backups",
+	c.run(sourceNamespaces, func() error {
+		if len(scope) > 0 {
+			// Listing namespaces is cluster-scoped, so fetch the named ones
+			for _, name := range scope {
+				ns, err := a.clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+				if apierrors.IsNotFound(err) {
+					// A mistyped or deleted namespace is a gap in the data,
+					// not an optional component that is not installed
+					return fmt.Errorf("namespace %s does not exist", name)
+				}
+				if err != nil {
+					return fmt.Errorf("namespace %s: %w", name, err)
+				}
+				ns.ManagedFields = nil
+				data.Namespaces = append(data.Namespaces, *ns)
+			}
+			return nil
 		}
 
-		backups, err := a.dynamicClient.Resource(veleroGVR).Namespace("").List(ctx, metav1.ListOptions{})
-		if err == nil && backups != nil {
-			data.VeleroBackups = backups.Items
+		if err := a.canList(ctx, "", "", "namespaces"); err != nil {
+			return err
 		}
-		// Ignore errors - Velero might not be installed
-	}
 
-	return data, nil
-}
+		namespaces, err := listPaged(ctx, pageSize, func(ctx context.Context, opts metav1.ListOptions) ([]corev1.Namespace, string, error) {
+			list, err := a.clientset.CoreV1().Namespaces().List(ctx, opts)
+			if err != nil {
+				return nil, "", err
+			}
+			for i := range list.Items {
+				list.Items[i].ManagedFields = nil
+			}
+			return list.Items, list.Continue, nil
+		})
+		data.Namespaces = namespaces
+		return err
+	})
 
-func (a *Analyzer) AnalyzeCluster(data *ClusterData) *Analysis {
-	analysis := &Analysis{}
+	// ReplicaSets and Jobs resolve pods to their Deployments and CronJobs; only
+	// their metadata is kept
+	c.run(sourceReplicaSets, func() error {
+		replicaSets, err := listScoped(ctx, a, "apps", "replicasets", func(ns string) func(context.Context, metav1.ListOptions) ([]appsv1.ReplicaSet, string, error) {
+			return func(ctx context.Context, opts metav1.ListOptions) ([]appsv1.ReplicaSet, string, error) {
+				list, err := a.clientset.AppsV1().ReplicaSets(ns).List(ctx, opts)
+				if err != nil {
+					return nil, "", err
+				}
+				items := make([]appsv1.ReplicaSet, len(list.Items))
+				for i, rs := range list.Items {
+					items[i].ObjectMeta = ownerMetadata(rs.ObjectMeta)
+				}
+				return items, list.Continue, nil
+			}
+		})
+		data.ReplicaSets = replicaSets
+		return err
+	})
 
-	// Analyze resource gaps
-	analysis.ResourceGaps = a.analyzeResourceGaps(data.Pods)
+	c.run(sourceJobs, func() error {
+		jobs, err := listScoped(ctx, a, "batch", "jobs", func(ns string) func(context.Context, metav1.ListOptions) ([]batchv1.Job, string, error) {
+			return func(ctx context.Context, opts metav1.ListOptions) ([]batchv1.Job, string, error) {
+				list, err := a.clientset.BatchV1().Jobs(ns).List(ctx, opts)
+				if err != nil {
+					return nil, "", err
+				}
+				items := make([]batchv1.Job, len(list.Items))
+				for i, job := range list.Items {
+					items[i].ObjectMeta = ownerMetadata(job.ObjectMeta)
+				}
+				return items, list.Continue, nil
+			}
+		})
+		data.Jobs = jobs
+		return err
+	})
 
-	// Analyze nodes
-	analysis.NodeIssues = a.analyzeNodes(data.Nodes, data.Pods)
+	if a.dynamicClient != nil {
+		// Pod metrics are best effort - metrics-server might not be available
+		c.run(sourcePodMetrics, func() error {
+			return a.collectPodMetrics(ctx, data)
+		})
 
-	// Analyze OOM events
-	analysis.OOMEvents = a.analyzeOOMEvents(data.Events)
+		if len(scope) > 0 {
+			c.skip(sourceNodeMetrics, reasonOutOfScope, "node metrics are cluster-scoped and not collected when the analysis is limited to namespaces")
+		} else {
+			c.run(sourceNodeMetrics, func() error {
+				return a.collectNodeMetrics(ctx, data)
+			})
+		}
 
-	// Analyze namespaces
-	analysis.NamespaceAnalysis = a.analyzeNamespaces(data.Pods, data.Namespaces)
+		c.run(sourceVeleroBackups, func() error {
+			veleroGVR := schema.GroupVersionResource{
+				Group:    "velero.io",
+				Version:  "v1",
+				Resource: "backups",
+			}
+
+			backups, err := listScoped(ctx, a, "velero.io", "backups", func(ns string) func(context.Context, metav1.ListOptions) ([]unstructured.Unstructured, string, error) {
+				return func(ctx context.Context, opts metav1.ListOptions) ([]unstructured.Unstructured, string, error) {
+					list, err := a.dynamicClient.Resource(veleroGVR).Namespace(ns).List(ctx, opts)
+					if err != nil {
+						return nil, "", err
+					}
+					return list.Items, list.GetContinue(), nil
+				}
+			})
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("velero.io API not available (Velero is not installed): %w", err)
+			}
+			data.VeleroBackups = backups
+			return err
+		})
 
-	// Analyze RabbitMQ
-	analysis.RabbitMQFindings = a.analyzeRabbitMQ(data.Pods)
+		// Resources required by enabled checks beyond the built-in sources
+		var mu sync.Mutex
+		for _, source := range a.config.Checks.customSources() {
+			c.run(source, func() error {
+				items, err := a.collectCustomResources(ctx, source)
+				if err != nil {
+					return err
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				if data.CustomResources == nil {
+					data.CustomResources = make(map[string][]unstructured.Unstructured)
+				}
+				data.CustomResources[source] = items
+				return nil
+			})
+		}
+	}
 
-	// Analyze short-lived jobs
-	analysis.ShortLivedJobs = a.analyzeJobs(data.Pods)
+	data.CollectionErrors = c.wait()
+	if err := collectionFailed(data.CollectionErrors, len(scope) > 0); err != nil {
+		return nil, err
+	}
 
-	// Analyze pod restarts
-	analysis.PodRestarts = a.analyzePodRestarts(data.Pods)
+	return data, nil
+}
 
-	// Analyze Flux events
-	analysis.FluxEvents = a.analyzeFluxEvents(data.Events)
+// AnalyzeCluster runs the enabled checks on the collected data, then
+// summarizes their findings.
+func (a *Analyzer) AnalyzeCluster(data *ClusterData) *Analysis {
+	analysis := &Analysis{Findings: []Finding{}}
+
+	// Namespace filters apply to workload analysis; node allocation always
+	// accounts for every pod scheduled on the node
+	workloads := newWorkloadResolver(data)
+	ran := a.runChecks(&CheckInput{
+		Data:      data,
+		Config:    a.config,
+		Analysis:  analysis,
+		Pods:      a.filterPods(data.Pods),
+		Events:    a.filterEvents(data.Events),
+		Workloads: workloads,
+		analyzer:  a,
+	})
 
-	// Analyze non-Flux events
-	analysis.NonFluxEvents = a.analyzeNonFluxEvents(data.Events)
+	sortFindings(analysis.Findings)
 
-	// Analyze Velero backups
-	analysis.VeleroBackups = a.analyzeVeleroBackups(data.VeleroBackups)
+	// Set aside findings accepted by annotations or the waivers file
+	analysis.Findings, analysis.SuppressedFindings, analysis.ExpiredWaivers = applyWaivers(
+		analysis.Findings, annotationWaivers(data, workloads), a.config.Waivers.entries, data.CollectedAt)
+	removeWaived(analysis)
 
-	// Generate cluster health summary
-	analysis.ClusterHealth = a.generateHealthSummary(analysis)
+	// Compare with the baseline report, leading with new findings
+	if baseline := a.config.Baseline.report; baseline != nil {
+		analysis.Baseline = compareBaseline(analysis, baseline, a.config.Baseline.File, ran)
+	}
 
 	// Generate critical issues
 	analysis.CriticalIssues = a.generateCriticalIssues(analysis)
 
+	// Score cluster health from the findings that were not waived
+	analysis.Health = healthScore(analysis.Findings)
+
 	return analysis
 }
 
-func (a *Analyzer) analyzePodRestarts(pods []corev1.Pod) PodRestartAnalysis {
+// filterPods returns the pods whose namespace passes the configured filters.
+func (a *Analyzer) filterPods(pods []corev1.Pod) []corev1.Pod {
+	filtered := make([]corev1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if a.config.Filters.Includes(pod.Namespace) {
+			filtered = append(filtered, pod)
+		}
+	}
+	return filtered
+}
+
+// filterEvents returns the events whose namespace passes the configured filters.
+func (a *Analyzer) filterEvents(events []corev1.Event) []corev1.Event {
+	filtered := make([]corev1.Event, 0, len(events))
+	for _, event := range events {
+		if a.config.Filters.Includes(event.Namespace) {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+func (a *Analyzer) analyzePodRestarts(pods []corev1.Pod, workloads *workloadResolver, now time.Time) PodRestartAnalysis {
 	analysis := PodRestartAnalysis{
 		Last24Hours: []PodRestart{},
 		Last7Days:   []PodRestart{},
 	}
 
-	now := time.Now()
 	threshold24h := now.Add(-24 * time.Hour)
 	threshold7d := now.Add(-7 * 24 * time.Hour)
 
 	for _, pod := range pods {
+		workload := workloads.resolve(&pod)
 		for _, containerStatus := range pod.Status.ContainerStatuses {
 			if containerStatus.RestartCount > 0 {
 				var lastRestartTime time.Time
@@ -336,6 +602,8 @@ func (a *Analyzer) analyzePodRestarts(pods []corev1.Pod) PodRestartAnalysis {
 
 				restart := PodRestart{
 					Namespace:       pod.Namespace,
+					Workload:        workload,
+					Pods:            1,
 					PodName:         pod.Name,
 					ContainerName:   containerStatus.Name,
 					RestartCount:    containerStatus.RestartCount,
@@ -356,15 +624,6 @@ func (a *Analyzer) analyzePodRestarts(pods []corev1.Pod) PodRestartAnalysis {
 		}
 	}
 
-	// Sort by restart count (highest first)
-	sort.Slice(analysis.Last24Hours, func(i, j int) bool {
-		return analysis.Last24Hours[i].RestartCount > analysis.Last24Hours[j].RestartCount
-	})
-
-	sort.Slice(analysis.Last7Days, func(i, j int) bool {
-		return analysis.Last7Days[i].RestartCount > analysis.Last7Days[j].RestartCount
-	})
-
 	// Count unique pods
 	uniquePods24h := make(map[string]bool)
 	for _, r := range analysis.Last24Hours {
@@ -378,16 +637,28 @@ func (a *Analyzer) analyzePodRestarts(pods []corev1.Pod) PodRestartAnalysis {
 	}
 	analysis.TotalPods7d = len(uniquePods7d)
 
+	// Report each workload container once
+	analysis.Last24Hours = mergeRestarts(analysis.Last24Hours)
+	analysis.Last7Days = mergeRestarts(analysis.Last7Days)
+
+	// Sort by restart count (highest first)
+	sort.Slice(analysis.Last24Hours, func(i, j int) bool {
+		return analysis.Last24Hours[i].RestartCount > analysis.Last24Hours[j].RestartCount
+	})
+
+	sort.Slice(analysis.Last7Days, func(i, j int) bool {
+		return analysis.Last7Days[i].RestartCount > analysis.Last7Days[j].RestartCount
+	})
+
 	return analysis
 }
 
-func (a *Analyzer) analyzeFluxEvents(events []corev1.Event) FluxEventAnalysis {
+func (a *Analyzer) analyzeFluxEvents(events []corev1.Event, now time.Time) FluxEventAnalysis {
 	analysis := FluxEventAnalysis{
 		Last24Hours: []EventInfo{},
 		Last48Hours: []EventInfo{},
 	}
 
-	now := time.Now()
 	threshold24h := now.Add(-24 * time.Hour)
 	threshold48h := now.Add(-48 * time.Hour)
 
@@ -409,13 +680,13 @@ func (a *Analyzer) analyzeFluxEvents(events []corev1.Event) FluxEventAnalysis {
 			Message:        event.Message,
 			Namespace:      event.Namespace,
 			InvolvedObject: fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name),
-			Count:          event.Count,
-			FirstTime:      event.FirstTimestamp.Time,
-			LastTime:       event.LastTimestamp.Time,
+			Count:          eventCount(&event),
+			FirstTime:      eventFirstTime(&event),
+			LastTime:       eventLastTime(&event),
 		}
 
 		// Count warnings and errors
-		if event.LastTimestamp.Time.After(threshold24h) {
+		if eventInfo.LastTime.After(threshold24h) {
 			analysis.Last24Hours = append(analysis.Last24Hours, eventInfo)
 			if event.Type == "Warning" {
 				analysis.Warnings24h++
@@ -424,7 +695,7 @@ func (a *Analyzer) analyzeFluxEvents(events []corev1.Event) FluxEventAnalysis {
 			}
 		}
 
-		if event.LastTimestamp.Time.After(threshold48h) {
+		if eventInfo.LastTime.After(threshold48h) {
 			analysis.Last48Hours = append(analysis.Last48Hours, eventInfo)
 			if event.Type == "Warning" {
 				analysis.Warnings48h++
@@ -446,13 +717,12 @@ func (a *Analyzer) analyzeFluxEvents(events []corev1.Event) FluxEventAnalysis {
 	return analysis
 }
 
-func (a *Analyzer) analyzeNonFluxEvents(events []corev1.Event) NonFluxEventAnalysis {
+func (a *Analyzer) analyzeNonFluxEvents(events []corev1.Event, now time.Time) NonFluxEventAnalysis {
 	analysis := NonFluxEventAnalysis{
 		Last24Hours: []EventInfo{},
 		Last48Hours: []EventInfo{},
 	}
 
-	now := time.Now()
 	threshold24h := now.Add(-24 * time.Hour)
 	threshold48h := now.Add(-48 * time.Hour)
 
@@ -479,17 +749,17 @@ func (a *Analyzer) analyzeNonFluxEvents(events []corev1.Event) NonFluxEventAnaly
 			Message:        event.Message,
 			Namespace:      event.Namespace,
 			InvolvedObject: fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name),
-			Count:          event.Count,
-			FirstTime:      event.FirstTimestamp.Time,
-			LastTime:       event.LastTimestamp.Time,
+			Count:          eventCount(&event),
+			FirstTime:      eventFirstTime(&event),
+			LastTime:       eventLastTime(&event),
 		}
 
-		if event.LastTimestamp.Time.After(threshold24h) {
+		if eventInfo.LastTime.After(threshold24h) {
 			analysis.Last24Hours = append(analysis.Last24Hours, eventInfo)
 			analysis.Warnings24h++
 		}
 
-		if event.LastTimestamp.Time.After(threshold48h) {
+		if eventInfo.LastTime.After(threshold48h) {
 			analysis.Last48Hours = append(analysis.Last48Hours, eventInfo)
 			analysis.Warnings48h++
 		}
@@ -507,13 +777,12 @@ func (a *Analyzer) analyzeNonFluxEvents(events []corev1.Event) NonFluxEventAnaly
 	return analysis
 }
 
-func (a *Analyzer) analyzeVeleroBackups(backups []unstructured.Unstructured) VeleroBackupAnalysis {
+func (a *Analyzer) analyzeVeleroBackups(backups []unstructured.Unstructured, now time.Time) VeleroBackupAnalysis {
 	analysis := VeleroBackupAnalysis{
 		Last24Hours: []VeleroBackup{},
 		Last48Hours: []VeleroBackup{},
 	}
 
-	now := time.Now()
 	threshold24h := now.Add(-24 * time.Hour)
 	threshold48h := now.Add(-48 * time.Hour)
 
@@ -593,15 +862,20 @@ func (a *Analyzer) analyzeVeleroBackups(backups []unstructured.Unstructured) Vel
 	return analysis
 }
 
-func (a *Analyzer) analyzeResourceGaps(pods []corev1.Pod) []ResourceGap {
+func (a *Analyzer) analyzeResourceGaps(pods []corev1.Pod, workloads *workloadResolver) []ResourceGap {
 	gaps := []ResourceGap{}
+	index := make(map[string]int) // workload container -> position in gaps
 
 	for _, pod := range pods {
-		for _, container := range pod.Spec.Containers {
+		workload := workloads.resolve(&pod)
+		for _, container := range resourceContainers(&pod) {
 			gap := ResourceGap{
-				Namespace: pod.Namespace,
-				PodName:   pod.Name,
-				Container: container.Name,
+				Namespace:     pod.Namespace,
+				Workload:      workload,
+				Replicas:      1,
+				PodName:       pod.Name,
+				Container:     container.Name,
+				ContainerType: container.Type,
 			}
 
 			if container.Resources.Requests == nil ||
@@ -616,78 +890,215 @@ func (a *Analyzer) analyzeResourceGaps(pods []corev1.Pod) []ResourceGap {
 				gap.MissingLimits = true
 			}
 
-			if gap.MissingRequests || gap.MissingLimits {
-				gaps = append(gaps, gap)
+			if !gap.MissingRequests && !gap.MissingLimits {
+				continue
+			}
+
+			// Replicas share a pod template, so report the workload container once
+			key := workloadKey(gap.Namespace, workload, container.Name)
+			if i, ok := index[key]; ok {
+				gaps[i].Replicas++
+				gaps[i].MissingRequests = gaps[i].MissingRequests || gap.MissingRequests
+				gaps[i].MissingLimits = gaps[i].MissingLimits || gap.MissingLimits
+				continue
 			}
+			index[key] = len(gaps)
+			gaps = append(gaps, gap)
 		}
 	}
 
 	return gaps
 }
 
-func (a *Analyzer) analyzeNodes(nodes []corev1.Node, pods []corev1.Pod) []NodeIssue {
+func (a *Analyzer) analyzeNodes(allocations []nodeAllocation) []NodeIssue {
 	issues := []NodeIssue{}
+	thresholds := a.config.Thresholds
+
+	for _, alloc := range allocations {
+		if alloc.CPUPercent > thresholds.NodeCPUThreshold {
+			cpuIssue := alloc.NodeIssue
+			cpuIssue.Issue = "High CPU requests"
+			cpuIssue.TopPods = topNodePods(alloc.pods, func(p NodePodRequest) float64 { return p.RequestedCPU })
+			issues = append(issues, cpuIssue)
+		}
 
-	for _, node := range nodes {
-		// Calculate resource usage on this node
-		var requestedCPU, requestedMemory float64
+		if alloc.MemoryPercent > thresholds.NodeMemoryThreshold {
+			memIssue := alloc.NodeIssue
+			memIssue.Issue = "High memory requests"
+			memIssue.TopPods = topNodePods(alloc.pods, func(p NodePodRequest) float64 { return p.RequestedMemory })
+			issues = append(issues, memIssue)
+		}
+	}
 
-		for _, pod := range pods {
-			if pod.Spec.NodeName == node.Name {
-				for _, container := range pod.Spec.Containers {
-					requestedCPU += float64(container.Resources.Requests.Cpu().MilliValue())
-					requestedMemory += float64(container.Resources.Requests.Memory().Value())
-				}
+	return issues
+}
+
+// nodeAllocation is the requested, limited, allocatable and used resources of a node.
+type nodeAllocation struct {
+	NodeIssue   // without Issue and TopPods
+	node        *corev1.Node
+	pods        []NodePodRequest
+	limitCPU    float64 // cores
+	limitMemory float64 // GiB
+	usage       *NodeMetrics
+
+	unboundedMemory int // running containers without a memory limit
+}
+
+// nodeAllocations sums the effective requests and limits of the pods on each
+// node and attaches its metrics, if collected. Only scheduled pods that have
+// not terminated hold resources on a node.
+func nodeAllocations(nodes []corev1.Node, pods []corev1.Pod, metrics map[string]NodeMetrics) []nodeAllocation {
+	podsByNode := make(map[string][]NodePodRequest)
+	limitsByNode := make(map[string]corev1.ResourceList)
+	unboundedByNode := make(map[string]int)
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || isTerminated(&pod) {
+			continue
+		}
+		requests := podRequests(&pod)
+		podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], NodePodRequest{
+			Namespace:       pod.Namespace,
+			PodName:         pod.Name,
+			RequestedCPU:    float64(requests.Cpu().MilliValue()) / 1000,
+			RequestedMemory: float64(requests.Memory().Value()) / (1024 * 1024 * 1024),
+		})
+
+		if limitsByNode[pod.Spec.NodeName] == nil {
+			limitsByNode[pod.Spec.NodeName] = corev1.ResourceList{}
+		}
+		addResources(limitsByNode[pod.Spec.NodeName], podLimits(&pod))
+
+		// Init containers run to completion, so only containers that keep
+		// running can grow without bound
+		for _, c := range resourceContainers(&pod) {
+			if _, ok := c.Resources.Limits[corev1.ResourceMemory]; !ok && c.Type != containerTypeInit {
+				unboundedByNode[pod.Spec.NodeName]++
 			}
 		}
+	}
 
-		allocatableCPU := float64(node.Status.Allocatable.Cpu().MilliValue())
-		allocatableMemory := float64(node.Status.Allocatable.Memory().Value())
+	allocations := make([]nodeAllocation, 0, len(nodes))
+	for i := range nodes {
+		node := &nodes[i]
+		var requestedCPU, requestedMemory float64
+		nodePods := podsByNode[node.Name]
+		for _, pod := range nodePods {
+			requestedCPU += pod.RequestedCPU
+			requestedMemory += pod.RequestedMemory
+		}
 
-		cpuPercent := (requestedCPU / allocatableCPU) * 100
-		memPercent := (requestedMemory / allocatableMemory) * 100
+		allocatableCPU := float64(node.Status.Allocatable.Cpu().MilliValue()) / 1000
+		allocatableMemory := float64(node.Status.Allocatable.Memory().Value()) / (1024 * 1024 * 1024)
 
-		if cpuPercent > 80 {
-			issues = append(issues, NodeIssue{
-				NodeName:          node.Name,
-				Issue:             "High CPU requests",
-				RequestedCPU:      requestedCPU / 1000,
-				RequestedMemory:   requestedMemory / (1024 * 1024 * 1024),
-				AllocatableCPU:    allocatableCPU / 1000,
-				AllocatableMemory: allocatableMemory / (1024 * 1024 * 1024),
-			})
+		limits := limitsByNode[node.Name]
+		var usage *NodeMetrics
+		if m, ok := metrics[node.Name]; ok {
+			usage = &m
 		}
 
-		if memPercent > 80 {
-			issues = append(issues, NodeIssue{
+		allocations = append(allocations, nodeAllocation{
+			NodeIssue: NodeIssue{
 				NodeName:          node.Name,
-				Issue:             "High memory requests",
-				RequestedCPU:      requestedCPU / 1000,
-				RequestedMemory:   requestedMemory / (1024 * 1024 * 1024),
-				AllocatableCPU:    allocatableCPU / 1000,
-				AllocatableMemory: allocatableMemory / (1024 * 1024 * 1024),
-			})
-		}
+				RequestedCPU:      requestedCPU,
+				RequestedMemory:   requestedMemory,
+				AllocatableCPU:    allocatableCPU,
+				AllocatableMemory: allocatableMemory,
+				CPUPercent:        percentOf(requestedCPU, allocatableCPU),
+				MemoryPercent:     percentOf(requestedMemory, allocatableMemory),
+				PodCount:          len(nodePods),
+			},
+			node:        node,
+			pods:        nodePods,
+			limitCPU:    float64(limits.Cpu().MilliValue()) / 1000,
+			limitMemory: float64(limits.Memory().Value()) / (1024 * 1024 * 1024),
+			usage:       usage,
+
+			unboundedMemory: unboundedByNode[node.Name],
+		})
 	}
+	return allocations
+}
 
-	return issues
+// isTerminated reports whether a pod has finished and released its resources.
+func isTerminated(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+// topNodePods returns the five pods with the largest value of a request.
+func topNodePods(pods []NodePodRequest, value func(NodePodRequest) float64) []NodePodRequest {
+	top := append([]NodePodRequest(nil), pods...)
+	sort.SliceStable(top, func(i, j int) bool { return value(top[i]) > value(top[j]) })
+	if len(top) > 5 {
+		top = top[:5]
+	}
+	return top
 }
 
-func (a *Analyzer) analyzeOOMEvents(events []corev1.Event) []OOMEvent {
+func percentOf(part, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return part / total * 100
+}
+
+// analyzeOOMEvents finds OOM kills in events and in the current and last
+// termination states of containers. A kill found in both is reported once,
+// with the container's memory limit and last observed usage.
+func (a *Analyzer) analyzeOOMEvents(events []corev1.Event, pods []corev1.Pod, metrics map[string]PodMetrics, workloads *workloadResolver) []OOMEvent {
 	oomEvents := []OOMEvent{}
 
+	for _, pod := range pods {
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			for _, terminated := range []*corev1.ContainerStateTerminated{status.State.Terminated, status.LastTerminationState.Terminated} {
+				if terminated == nil || terminated.Reason != "OOMKilled" {
+					continue
+				}
+				oomEvents = append(oomEvents, OOMEvent{
+					NodeName:    pod.Spec.NodeName,
+					PodName:     pod.Name,
+					Namespace:   pod.Namespace,
+					Workload:    workloads.resolve(&pod),
+					Container:   status.Name,
+					Timestamp:   terminated.FinishedAt.Time,
+					Reason:      terminated.Reason,
+					Source:      oomSourceStatus,
+					ExitCode:    terminated.ExitCode,
+					MemoryLimit: containerMemoryLimit(&pod, status.Name),
+					MemoryUsage: metrics[pod.Namespace+"/"+pod.Name].Containers[status.Name].MemoryUsage,
+				})
+			}
+		}
+	}
+	fromStatuses := len(oomEvents)
+
 	for _, event := range events {
-		if strings.Contains(event.Reason, "OOMKilled") ||
-			strings.Contains(event.Message, "OOMKilled") {
-			oomEvents = append(oomEvents, OOMEvent{
-				NodeName:  event.Source.Host,
-				PodName:   event.InvolvedObject.Name,
-				Namespace: event.InvolvedObject.Namespace,
-				Container: event.InvolvedObject.FieldPath,
-				Timestamp: event.LastTimestamp.Time,
-				Reason:    event.Reason,
-			})
+		if !strings.Contains(event.Reason, "OOMKilled") &&
+			!strings.Contains(event.Message, "OOMKilled") {
+			continue
 		}
+
+		oom := OOMEvent{
+			NodeName:  event.Source.Host,
+			PodName:   event.InvolvedObject.Name,
+			Namespace: event.InvolvedObject.Namespace,
+			Workload:  workloads.resolveObject("Pod", event.InvolvedObject.Namespace, event.InvolvedObject.Name),
+			Container: fieldPathContainer(event.InvolvedObject.FieldPath),
+			Timestamp: eventLastTime(&event),
+			Reason:    event.Reason,
+			Source:    oomSourceEvent,
+		}
+
+		// Skip events for a kill already found in a container status
+		if i := matchingOOMKill(oomEvents[:fromStatuses], event, oom.Container); i >= 0 {
+			oomEvents[i].Source = oomSourceBoth
+			if oomEvents[i].NodeName == "" {
+				oomEvents[i].NodeName = oom.NodeName
+			}
+			continue
+		}
+		oomEvents = append(oomEvents, oom)
 	}
 
 	// Sort by timestamp, most recent first
@@ -698,20 +1109,70 @@ func (a *Analyzer) analyzeOOMEvents(events []corev1.Event) []OOMEvent {
 	return oomEvents
 }
 
-func (a *Analyzer) analyzeNamespaces(pods []corev1.Pod, namespaces []corev1.Namespace) []NamespaceAnalysis {
+// matchingOOMKill returns the index of the kill found in a container status
+// that an event reports, or -1. The container finished within a minute of
+// the time range the event was seen in.
+func matchingOOMKill(kills []OOMEvent, event corev1.Event, container string) int {
+	first, last := eventFirstTime(&event), eventLastTime(&event)
+	for i, kill := range kills {
+		if kill.Namespace != event.InvolvedObject.Namespace || kill.PodName != event.InvolvedObject.Name {
+			continue
+		}
+		if container != "" && kill.Container != container {
+			continue
+		}
+		if kill.Timestamp.Before(first.Add(-time.Minute)) || kill.Timestamp.After(last.Add(time.Minute)) {
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+// fieldPathContainer returns the container name of an event's field path,
+// e.g. "app" for "spec.containers{app}", or the field path unchanged.
+func fieldPathContainer(fieldPath string) string {
+	open := strings.Index(fieldPath, "{")
+	if open < 0 || !strings.HasSuffix(fieldPath, "}") {
+		return fieldPath
+	}
+	return fieldPath[open+1 : len(fieldPath)-1]
+}
+
+// containerMemoryLimit returns the memory limit of a container, or "" when
+// it has none.
+func containerMemoryLimit(pod *corev1.Pod, name string) string {
+	for _, c := range resourceContainers(pod) {
+		if c.Name != name {
+			continue
+		}
+		if limit, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
+			return limit.String()
+		}
+	}
+	return ""
+}
+
+// analyzeNamespaces rates the selected application namespaces by the share of
+// pods without requests, and returns the namespaces left out with the reason.
+func (a *Analyzer) analyzeNamespaces(pods []corev1.Pod, namespaces []corev1.Namespace, workloads *workloadResolver) ([]NamespaceAnalysis, []ExcludedNamespace) {
 	nsMap := make(map[string]*NamespaceAnalysis)
+	criticalWorkloads := make(map[string]map[Workload]int) // namespace -> workload -> pods without requests
 
-	// Filter application namespaces (3-letter codes)
-	appNamespaces := []string{}
-	for _, ns := range namespaces {
-		if len(ns.Name) == 3 && !strings.HasPrefix(ns.Name, "kube-") {
-			appNamespaces = append(appNamespaces, ns.Name)
-			nsMap[ns.Name] = &NamespaceAnalysis{
-				Namespace:       ns.Name,
-				Recommendations: []string{},
-				CriticalPods:    []string{},
-			}
+	// Select application namespaces by the configured filters; Validate has
+	// already checked that the selector compiles
+	selector, err := newNamespaceSelector(a.config.Filters)
+	if err != nil {
+		selector = &namespaceSelector{filters: a.config.Filters}
+	}
+	appNamespaces, excluded := selector.selectNamespaces(namespaces)
+	for _, name := range appNamespaces {
+		nsMap[name] = &NamespaceAnalysis{
+			Namespace:       name,
+			Recommendations: []string{},
+			CriticalPods:    []string{},
 		}
+		criticalWorkloads[name] = make(map[Workload]int)
 	}
 
 	// Analyze pods in each namespace
@@ -722,7 +1183,7 @@ func (a *Analyzer) analyzeNamespaces(pods []corev1.Pod, namespaces []corev1.Name
 			hasRequests := false
 			hasLimits := false
 
-			for _, container := range pod.Spec.Containers {
+			for _, container := range resourceContainers(&pod) {
 				if container.Resources.Requests != nil &&
 					(!container.Resources.Requests.Cpu().IsZero() ||
 						!container.Resources.Requests.Memory().IsZero()) {
@@ -738,6 +1199,7 @@ func (a *Analyzer) analyzeNamespaces(pods []corev1.Pod, namespaces []corev1.Name
 			if !hasRequests {
 				nsAnalysis.PodsWithoutRequests++
 				nsAnalysis.CriticalPods = append(nsAnalysis.CriticalPods, pod.Name)
+				criticalWorkloads[pod.Namespace][workloads.resolve(&pod)]++
 			}
 			if !hasLimits {
 				nsAnalysis.PodsWithoutLimits++
@@ -749,16 +1211,20 @@ func (a *Analyzer) analyzeNamespaces(pods []corev1.Pod, namespaces []corev1.Name
 	result := []NamespaceAnalysis{}
 	for _, nsAnalysis := range nsMap {
 		if nsAnalysis.TotalPods == 0 {
+			excluded = append(excluded, ExcludedNamespace{Namespace: nsAnalysis.Namespace, Reason: "no pods"})
 			continue
 		}
 
+		nsAnalysis.CriticalWorkloads = summarizeWorkloads(criticalWorkloads[nsAnalysis.Namespace])
+
 		requestGapPercent := float64(nsAnalysis.PodsWithoutRequests) / float64(nsAnalysis.TotalPods) * 100
+		thresholds := a.config.Thresholds
 
-		if requestGapPercent > 75 {
+		if requestGapPercent > thresholds.CriticalRisk {
 			nsAnalysis.RiskLevel = "critical"
-		} else if requestGapPercent > 50 {
+		} else if requestGapPercent > thresholds.HighRisk {
 			nsAnalysis.RiskLevel = "high"
-		} else if requestGapPercent > 25 {
+		} else if requestGapPercent > thresholds.MediumRisk {
 			nsAnalysis.RiskLevel = "medium"
 		} else {
 			nsAnalysis.RiskLevel = "low"
@@ -772,25 +1238,40 @@ func (a *Analyzer) analyzeNamespaces(pods []corev1.Pod, namespaces []corev1.Name
 		riskOrder := map[string]int{"critical": 0, "high": 1, "medium": 2, "low": 3}
 		return riskOrder[result[i].RiskLevel] < riskOrder[result[j].RiskLevel]
 	})
+	sort.Slice(excluded, func(i, j int) bool { return excluded[i].Namespace < excluded[j].Namespace })
 
-	return result
+	return result, excluded
 }
 
-func (a *Analyzer) analyzeRabbitMQ(pods []corev1.Pod) RabbitMQAnalysis {
+func (a *Analyzer) analyzeRabbitMQ(pods []corev1.Pod, workloads *workloadResolver) RabbitMQAnalysis {
 	analysis := RabbitMQAnalysis{
 		RabbitMQPods:    []string{},
+		Workloads:       []RabbitMQWorkload{},
 		Recommendations: []string{},
 	}
+	index := make(map[string]int) // namespace/Kind/name -> index in Workloads
 
 	for _, pod := range pods {
-		if strings.Contains(pod.Name, "rabbitmq") ||
-			strings.Contains(strings.ToLower(pod.Name), "rabbit") {
+		if a.isRabbitMQPod(pod) {
 			analysis.RabbitMQPods = append(analysis.RabbitMQPods,
 				fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
 
-			// Check priority class
-			if pod.Spec.PriorityClassName != "" {
+			workload := workloads.resolve(&pod)
+			key := pod.Namespace + "/" + workload.String()
+			i, ok := index[key]
+			if !ok {
+				i = len(analysis.Workloads)
+				index[key] = i
+				analysis.Workloads = append(analysis.Workloads, RabbitMQWorkload{Namespace: pod.Namespace, Workload: workload})
+			}
+			w := &analysis.Workloads[i]
+			w.Pods++
+
+			// Check priority class (and that it actually outranks regular workloads)
+			if pod.Spec.PriorityClassName != "" &&
+				(pod.Spec.Priority == nil || *pod.Spec.Priority >= a.config.Thresholds.CriticalPriority) {
 				analysis.HasPriorityClass = true
+				w.HasPriorityClass = true
 			}
 
 			// Check resource limits
@@ -798,6 +1279,7 @@ func (a *Analyzer) analyzeRabbitMQ(pods []corev1.Pod) RabbitMQAnalysis {
 				if container.Resources.Limits != nil &&
 					!container.Resources.Limits.Memory().IsZero() {
 					analysis.HasResourceLimits = true
+					w.HasMemoryLimit = true
 					break
 				}
 			}
@@ -807,8 +1289,20 @@ func (a *Analyzer) analyzeRabbitMQ(pods []corev1.Pod) RabbitMQAnalysis {
 	return analysis
 }
 
-func (a *Analyzer) analyzeJobs(pods []corev1.Pod) JobAnalysis {
-	analysis := JobAnalysis{}
+func (a *Analyzer) isRabbitMQPod(pod corev1.Pod) bool {
+	name := strings.ToLower(pod.Name)
+	for _, keyword := range a.config.RabbitMQ.Keywords {
+		if keyword != "" && strings.Contains(name, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Analyzer) analyzeJobs(pods []corev1.Pod, workloads *workloadResolver) JobAnalysis {
+	analysis := JobAnalysis{Workloads: []ShortLivedJob{}}
+	shortJobDuration := time.Duration(a.config.Thresholds.ShortJobDuration * float64(time.Minute))
+	index := make(map[string]int) // namespace/Kind/name -> index in Workloads
 
 	for _, pod := range pods {
 		if pod.OwnerReferences != nil {
@@ -816,15 +1310,27 @@ func (a *Analyzer) analyzeJobs(pods []corev1.Pod) JobAnalysis {
 				if owner.Kind == "Job" {
 					analysis.TotalJobs++
 
-					// Check if short-lived (completed in < 2 minutes)
+					// Count job pods per Job, or per CronJob for the Jobs it creates
+					workload := workloads.resolve(&pod)
+					key := pod.Namespace + "/" + workload.String()
+					i, ok := index[key]
+					if !ok {
+						i = len(analysis.Workloads)
+						index[key] = i
+						analysis.Workloads = append(analysis.Workloads, ShortLivedJob{Namespace: pod.Namespace, Workload: workload})
+					}
+					analysis.Workloads[i].TotalJobs++
+
+					// Check if short-lived (completed in < short_job_duration)
 					if pod.Status.Phase == "Succeeded" &&
 						pod.Status.StartTime != nil &&
 						pod.Status.ContainerStatuses != nil {
 						for _, cs := range pod.Status.ContainerStatuses {
 							if cs.State.Terminated != nil {
 								duration := cs.State.Terminated.FinishedAt.Sub(pod.Status.StartTime.Time)
-								if duration < 2*time.Minute {
+								if duration < shortJobDuration {
 									analysis.ShortJobs++
+									analysis.Workloads[i].ShortJobs++
 									break
 								}
 							}
@@ -835,95 +1341,186 @@ func (a *Analyzer) analyzeJobs(pods []corev1.Pod) JobAnalysis {
 		}
 	}
 
+	// Keep the workloads with short-lived pods, most first
+	short := analysis.Workloads[:0]
+	for _, w := range analysis.Workloads {
+		if w.ShortJobs > 0 {
+			short = append(short, w)
+		}
+	}
+	sort.SliceStable(short, func(i, j int) bool { return short[i].ShortJobs > short[j].ShortJobs })
+	analysis.Workloads = short
+
 	return analysis
 }
 
-func (a *Analyzer) generateHealthSummary(analysis *Analysis) string {
-	criticalCount := 0
-	for _, issue := range analysis.CriticalIssues {
-		if issue.Priority <= 2 {
-			criticalCount++
+// criticalIssueRules are the rules whose findings are raised as critical
+// issues, in report order within a priority. Other rules are raised only for
+// critical findings.
+var criticalIssueRules = []string{
+	ruleNodeNotReady,
+	ruleNodeNetworkUnavailable,
+	ruleMissingRequests,
+	ruleOOMKilled,
+	ruleNodeMemoryPressure,
+	ruleNodeDiskPressure,
+	ruleNodePIDPressure,
+	ruleMissingLimits,
+	ruleNodeHighCPURequests,
+	ruleNodeHighMemoryRequests,
+	ruleNodePoolOutlier,
+	ruleNodeCordoned,
+}
+
+// generateCriticalIssues summarizes the findings that were not waived as one
+// issue per rule, most urgent first.
+func (a *Analyzer) generateCriticalIssues(analysis *Analysis) []CriticalIssue {
+	byRule := make(map[string][]Finding)
+	var order []string
+	for _, f := range analysis.Findings {
+		if _, ok := byRule[f.RuleID]; !ok {
+			order = append(order, f.RuleID)
 		}
+		byRule[f.RuleID] = append(byRule[f.RuleID], f)
 	}
 
-	health := "healthy"
-	if criticalCount > 3 || len(analysis.OOMEvents) > 10 {
-		health = "critical"
-	} else if criticalCount > 0 || len(analysis.OOMEvents) > 0 {
-		health = "degraded"
+	issues := []CriticalIssue{}
+	listed := make(map[string]bool)
+	for _, ruleID := range criticalIssueRules {
+		listed[ruleID] = true
+		if findings := byRule[ruleID]; len(findings) > 0 {
+			issues = append(issues, a.criticalIssue(ruleID, findings, analysis))
+		}
+	}
+	// Critical findings of other rules, e.g. of checks registered elsewhere
+	for _, ruleID := range order {
+		if !listed[ruleID] && countBySeverity(byRule[ruleID])[severityCritical] > 0 {
+			issues = append(issues, a.criticalIssue(ruleID, byRule[ruleID], analysis))
+		}
 	}
 
-	return health
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Priority < issues[j].Priority })
+	return issues
 }
 
-func (a *Analyzer) generateCriticalIssues(analysis *Analysis) []CriticalIssue {
-	issues := []CriticalIssue{}
-
-	// Issue 1: Missing resource requests/limits
-	if len(analysis.ResourceGaps) > 0 {
-		examples := []string{}
-		for i, gap := range analysis.ResourceGaps {
-			if i >= 3 {
-				break
-			}
-			examples = append(examples, fmt.Sprintf("%s/%s (container: %s)",
-				gap.Namespace, gap.PodName, gap.Container))
+// criticalIssue summarizes the findings of one rule.
+func (a *Analyzer) criticalIssue(ruleID string, findings []Finding, analysis *Analysis) CriticalIssue {
+	examples := []string{}
+	for i, f := range findings {
+		if i >= 3 {
+			break
 		}
-
-		issues = append(issues, CriticalIssue{
-			Priority:       1,
-			Title:          "Missing Resource Requests and Limits",
-			Description:    fmt.Sprintf("%d containers are missing resource requests or limits", len(analysis.ResourceGaps)),
-			Impact:         "Prevents proper scheduling, impacts Velero backups, and can cause cluster instability",
-			Recommendation: "Set resource requests and limits for all containers based on observed usage patterns",
-			Examples:       examples,
-		})
+		examples = append(examples, f.Message)
 	}
-
-	// Issue 2: OOM events
-	if len(analysis.OOMEvents) > 0 {
-		examples := []string{}
-		for i, event := range analysis.OOMEvents {
-			if i >= 3 {
-				break
+	issue := CriticalIssue{RuleID: ruleID, Examples: examples}
+	n := len(findings)
+
+	switch ruleID {
+	case ruleNodeNotReady:
+		issue.Priority = 1
+		issue.Title = "Nodes Not Ready"
+		issue.Description = fmt.Sprintf("%s not ready", pluralize(n, "node"))
+		issue.Impact = "Pods on these nodes are unreachable or being evicted, and the cluster has less capacity than it appears to"
+		issue.Recommendation = "Check the kubelet and container runtime on the nodes (kubectl describe node), and replace nodes that do not recover"
+
+	case ruleNodeNetworkUnavailable:
+		issue.Priority = 1
+		issue.Title = "Node Network Unavailable"
+		issue.Description = fmt.Sprintf("%s without working pod networking", pluralize(n, "node"))
+		issue.Impact = "Pods on these nodes cannot reach or be reached by the rest of the cluster"
+		issue.Recommendation = "Check the CNI plugin and routes on the nodes, and replace nodes that do not recover"
+
+	case ruleMissingRequests:
+		issue.Priority = 1
+		issue.Title = "Missing Resource Requests"
+		issue.Description = fmt.Sprintf("%s missing resource requests", pluralize(n, "workload container"))
+		issue.Impact = "Prevents proper scheduling, impacts Velero backups, and makes the pods the first to be evicted under pressure"
+		issue.Recommendation = "Set CPU and memory requests for all containers, including init and sidecar containers, based on observed usage patterns"
+
+	case ruleOOMKilled:
+		kills := 0
+		for _, f := range findings {
+			k, err := strconv.Atoi(f.Evidence["kills"])
+			if err != nil {
+				k = 1
 			}
-			examples = append(examples, fmt.Sprintf("%s/%s at %s",
-				event.Namespace, event.PodName, event.Timestamp.Format(time.RFC3339)))
+			kills += k
+		}
+		issue.Priority = 2
+		issue.Title = "OOMKilled Events Detected"
+		issue.Description = fmt.Sprintf("%s of %s found in recent history", pluralize(kills, "OOM kill"), pluralize(n, "workload container"))
+		if overcommitted, total := oomKillsOnOvercommitted(analysis.NodeOOMRisk); total > 0 {
+			issue.Description += fmt.Sprintf("; %d of %d with a known node were on nodes whose memory limits exceed allocatable memory", overcommitted, total)
+		}
+		issue.Impact = "Workload disruptions, data loss, and degraded application performance"
+		issue.Recommendation = "Increase memory limits for affected pods or optimize application memory usage"
+
+	case ruleNodeMemoryPressure:
+		issue.Priority = 2
+		issue.Title = "Node Memory Pressure"
+		issue.Description = fmt.Sprintf("%s reporting memory pressure", pluralize(n, "node"))
+		issue.Impact = "The kubelet evicts pods from nodes under pressure, starting with pods that use more than they request"
+		issue.Recommendation = "Set memory requests close to actual usage and add capacity to the node pool"
+
+	case ruleNodeDiskPressure:
+		issue.Priority = 2
+		issue.Title = "Node Disk Pressure"
+		issue.Description = fmt.Sprintf("%s reporting disk pressure", pluralize(n, "node"))
+		issue.Impact = "The kubelet evicts pods from nodes under pressure and stops pulling images"
+		issue.Recommendation = "Clean up disk usage (images, logs, emptyDir) or grow the nodes' disks"
+
+	case ruleNodePIDPressure:
+		issue.Priority = 2
+		issue.Title = "Node PID Pressure"
+		issue.Description = fmt.Sprintf("%s reporting PID pressure", pluralize(n, "node"))
+		issue.Impact = "The kubelet evicts pods from nodes under pressure, and new processes fail to start"
+		issue.Recommendation = "Find workloads leaking processes and set pod PID limits"
+
+	case ruleMissingLimits:
+		issue.Priority = 2
+		issue.Title = "Missing Resource Limits"
+		issue.Description = fmt.Sprintf("%s missing resource limits", pluralize(n, "workload container"))
+		issue.Impact = "A container without a memory limit can use up its node's memory and get other pods evicted or OOM killed"
+		issue.Recommendation = "Set memory limits for all containers, and CPU limits where throttling is acceptable, based on observed usage patterns"
+
+	case ruleNodeHighCPURequests, ruleNodeHighMemoryRequests:
+		issue.Priority = 3
+		issue.Title = "High Node CPU Requests"
+		issue.Description = fmt.Sprintf("%s with CPU requests above the node threshold", pluralize(n, "node"))
+		if ruleID == ruleNodeHighMemoryRequests {
+			issue.Title = "High Node Memory Requests"
+			issue.Description = fmt.Sprintf("%s with memory requests above the node threshold", pluralize(n, "node"))
+		}
+		issue.Impact = "Limited scheduling capacity, potential cascading failures during node issues"
+		issue.Recommendation = "Scale node pool or rebalance workloads across nodes"
+		if overReserved := nodesAssessed(analysis.NodeUtilization, nodeOverReserved); len(overReserved) > 0 {
+			issue.Recommendation += fmt.Sprintf("; %s over-reserved (high requests, low actual usage), so lower requests there before adding capacity",
+				pluralize(len(overReserved), "node"))
 		}
 
-		issues = append(issues, CriticalIssue{
-			Priority:       2,
-			Title:          "OOMKilled Events Detected",
-			Description:    fmt.Sprintf("%d OOMKilled events found in recent history", len(analysis.OOMEvents)),
-			Impact:         "Workload disruptions, data loss, and degraded application performance",
-			Recommendation: "Increase memory limits for affected pods or optimize application memory usage",
-			Examples:       examples,
-		})
-	}
-
-	// Issue 3: Node resource pressure
-	if len(analysis.NodeIssues) > 0 {
-		examples := []string{}
-		for i, issue := range analysis.NodeIssues {
-			if i >= 3 {
-				break
-			}
-			examples = append(examples, fmt.Sprintf("%s: %s (%.1f%% utilized)",
-				issue.NodeName, issue.Issue,
-				(issue.RequestedCPU/issue.AllocatableCPU)*100))
-		}
-
-		issues = append(issues, CriticalIssue{
-			Priority:       3,
-			Title:          "High Node Resource Utilization",
-			Description:    fmt.Sprintf("%d nodes showing high resource utilization", len(analysis.NodeIssues)),
-			Impact:         "Limited scheduling capacity, potential cascading failures during node issues",
-			Recommendation: "Scale node pool or rebalance workloads across nodes",
-			Examples:       examples,
-		})
+	case ruleNodePoolOutlier:
+		issue.Priority = 3
+		issue.Title = "Poorly Balanced Node Pools"
+		issue.Description = fmt.Sprintf("%s have requests that differ from their pool average by more than %.0f percentage points",
+			pluralize(n, "node resource"), a.config.Thresholds.NodePoolOutlier)
+		issue.Impact = "Hot nodes run out of room for new pods and are evicted first under pressure while capacity elsewhere in the pool sits idle"
+		issue.Recommendation = "Spread replicas with topology spread constraints or pod anti-affinity, and let the descheduler rebalance long-running pods"
+
+	case ruleNodeCordoned:
+		issue.Priority = 4
+		issue.Title = "Cordoned Nodes"
+		issue.Description = fmt.Sprintf("%s cordoned and not accepting new pods", pluralize(n, "node"))
+		issue.Impact = "Reduces schedulable capacity; nodes left cordoned after maintenance are easy to forget"
+		issue.Recommendation = "Uncordon nodes once maintenance is done (kubectl uncordon) or drain and remove them"
+
+	default:
+		issue.Priority = 1
+		issue.Title = findings[0].Title
+		issue.Description = fmt.Sprintf("%s of rule %s", pluralize(n, "finding"), ruleID)
+		issue.Impact = "Critical findings make the cluster health critical"
+		issue.Recommendation = findings[0].Remediation
 	}
-
-	return issues
+	return issue
 }
 
 func (a *Analyzer) collectPodResourceInfo(ctx context.Context, pods []corev1.Pod) []PodResourceInfo {
@@ -947,11 +1544,12 @@ func (a *Analyzer) collectPodResourceInfo(ctx context.Context, pods []corev1.Pod
 			continue
 		}
 
-		for _, container := range pod.Spec.Containers {
+		for _, container := range resourceContainers(&pod) {
 			podInfo := PodResourceInfo{
 				Namespace:     pod.Namespace,
 				PodName:       pod.Name,
 				ContainerName: container.Name,
+				ContainerType: container.Type,
 				Status:        string(pod.Status.Phase),
 			}
 
@@ -1040,6 +1638,11 @@ func (a *Analyzer) getClusterName(ctx context.Context) string {
 		}
 	}
 
+	// Nodes are cluster-scoped, so skip the node label fallback in namespace-scoped mode
+	if len(a.config.Kubernetes.Namespaces()) > 0 {
+		return "Unknown Cluster"
+	}
+
 	// Fallback: try to extract from server URL or use "Unknown"
 	// Get the first node and check for labels
 	nodes, err := a.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{Limit: 1})
@@ -1064,7 +1667,7 @@ func (a *Analyzer) getClusterName(ctx context.Context) string {
 	return "Unknown Cluster"
 }
 
-func (a *Analyzer) collectPodMetrics(ctx context.Context, data *ClusterData) {
+func (a *Analyzer) collectPodMetrics(ctx context.Context, data *ClusterData) error {
 	// Try to get metrics from metrics-server using dynamic client
 	metricsGVR := schema.GroupVersionResource{
 		Group:    "metrics.k8s.io",
@@ -1072,14 +1675,24 @@ func (a *Analyzer) collectPodMetrics(ctx context.Context, data *ClusterData) {
 		Resource: "pods",
 	}
 
-	metricsList, err := a.dynamicClient.Resource(metricsGVR).Namespace("").List(ctx, metav1.ListOptions{})
+	items, err := listScoped(ctx, a, "metrics.k8s.io", "pods", func(ns string) func(context.Context, metav1.ListOptions) ([]unstructured.Unstructured, string, error) {
+		return func(ctx context.Context, opts metav1.ListOptions) ([]unstructured.Unstructured, string, error) {
+			list, err := a.dynamicClient.Resource(metricsGVR).Namespace(ns).List(ctx, opts)
+			if err != nil {
+				return nil, "", err
+			}
+			return list.Items, list.GetContinue(), nil
+		}
+	})
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("metrics API not available (is metrics-server installed?): %w", err)
+	}
 	if err != nil {
-		// Metrics-server not available or error fetching metrics
-		return
+		return err
 	}
 
 	// Parse metrics
-	for _, item := range metricsList.Items {
+	for _, item := range items {
 		namespace, _, _ := unstructured.NestedString(item.Object, "metadata", "namespace")
 		name, _, _ := unstructured.NestedString(item.Object, "metadata", "name")
 
@@ -1118,4 +1731,43 @@ func (a *Analyzer) collectPodMetrics(ctx context.Context, data *ClusterData) {
 		key := namespace + "/" + name
 		data.PodMetrics[key] = podMetrics
 	}
+	return nil
+}
+
+func (a *Analyzer) collectNodeMetrics(ctx context.Context, data *ClusterData) error {
+	if err := a.canList(ctx, "", "metrics.k8s.io", "nodes"); err != nil {
+		return err
+	}
+
+	metricsGVR := schema.GroupVersionResource{
+		Group:    "metrics.k8s.io",
+		Version:  "v1beta1",
+		Resource: "nodes",
+	}
+
+	items, err := listPaged(ctx, a.config.Kubernetes.PageSize, func(ctx context.Context, opts metav1.ListOptions) ([]unstructured.Unstructured, string, error) {
+		list, err := a.dynamicClient.Resource(metricsGVR).List(ctx, opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return list.Items, list.GetContinue(), nil
+	})
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("metrics API not available (is metrics-server installed?): %w", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		usage, found, _ := unstructured.NestedStringMap(item.Object, "usage")
+		if !found {
+			continue
+		}
+		data.NodeMetrics[item.GetName()] = NodeMetrics{
+			CPUUsage:    usage["cpu"],
+			MemoryUsage: usage["memory"],
+		}
+	}
+	return nil
 }
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestAnalyzeClusterSnapshot analyzes the fixture snapshot in testdata: a
+// Deployment whose container was OOM killed and has no limits, a pod with an
+// init container and a sidecar, a kube-system pod without resources and a
+// node under memory pressure.
+func TestAnalyzeClusterSnapshot(t *testing.T) {
+	data, err := ReadSnapshot("testdata/cluster.snapshot.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	analysis := NewAnalyzer(nil, nil, DefaultConfig()).AnalyzeCluster(data)
+
+	wantFindings := []string{
+		"nodes/memory-pressure:Node/n1",
+		"resources/missing-requests:Pod/kube-system/coredns-5d78c9869d-x2x4k/coredns",
+		"stability/oom-killed:Deployment/app/web/app",
+		"nodes/oom-risk:Node/n1",
+		"nodes/oom-risk:Node/n2",
+		"resources/missing-limits:Deployment/app/web/app",
+		"resources/missing-limits:Pod/kube-system/coredns-5d78c9869d-x2x4k/coredns",
+		"stability/restarts:Deployment/app/web/app",
+		"events/warning:Deployment/app/web/BackOff",
+		"events/warning:Deployment/app/web/OOMKilled",
+	}
+	var got []string
+	for _, f := range analysis.Findings {
+		got = append(got, f.ID)
+	}
+	if !reflect.DeepEqual(got, wantFindings) {
+		t.Errorf("findings = %v\nwant %v", got, wantFindings)
+	}
+
+	// The kill of the replaced pod counts towards the same Deployment
+	for _, f := range analysis.Findings {
+		if f.RuleID == ruleOOMKilled && f.Evidence["kills"] != "2" {
+			t.Errorf("%s kills = %s, want 2", f.ID, f.Evidence["kills"])
+		}
+	}
+
+	// One critical issue per rule, most urgent first; limits-only gaps are
+	// not reported as missing requests
+	wantIssues := []string{ruleMissingRequests, ruleOOMKilled, ruleNodeMemoryPressure, ruleMissingLimits}
+	var issues []string
+	for _, issue := range analysis.CriticalIssues {
+		issues = append(issues, issue.RuleID)
+	}
+	if !reflect.DeepEqual(issues, wantIssues) {
+		t.Errorf("critical issues = %v, want %v", issues, wantIssues)
+	}
+
+	if analysis.Health.Score != 48 || analysis.Health.Status != healthCritical {
+		t.Errorf("health = %s, want CRITICAL (48/100)", analysis.Health)
+	}
+
+	// The worker's init container runs before its sidecar starts, so the pod
+	// requests max(setup 1, proxy 100m + main 200m)
+	for i := range data.Pods {
+		if pod := &data.Pods[i]; pod.Name == "worker" {
+			if requests := podRequests(pod)[corev1.ResourceCPU]; requests.String() != "1" {
+				t.Errorf("worker requests %s CPU, want 1", requests.String())
+			}
+		}
+	}
+
+	// Only web-7d9f-def34's container runs on n2 without a memory limit
+	for _, node := range analysis.NodeOOMRisk {
+		if node.NodeName == "n2" && node.UnboundedContainers != 1 {
+			t.Errorf("n2 unbounded containers = %d, want 1", node.UnboundedContainers)
+		}
+	}
+	if len(analysis.SkippedAnalyses) != 2 {
+		t.Errorf("skipped analyses = %+v, want node-utilization and velero-backups", analysis.SkippedAnalyses)
+	}
+}
+
+func TestAnalyzeOOMEvents(t *testing.T) {
+	killedAt := time.Date(2026, 10, 1, 11, 30, 0, 0, time.UTC)
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "app"}}
+	pod.Spec.NodeName = "n1"
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name: "app",
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			Reason: "OOMKilled", ExitCode: 137, FinishedAt: metav1.NewTime(killedAt),
+		}},
+	}}
+
+	event := func(reason, podName, container string, at time.Time) corev1.Event {
+		return corev1.Event{
+			Reason:         reason,
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "app", Name: podName, FieldPath: "spec.containers{" + container + "}"},
+			Source:         corev1.EventSource{Host: "n1"},
+			FirstTimestamp: metav1.NewTime(at),
+			LastTimestamp:  metav1.NewTime(at),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		events      []corev1.Event
+		wantSources []string // sources of the kills found, most recent first
+	}{
+		{
+			name:        "container status only",
+			wantSources: []string{oomSourceStatus},
+		},
+		{
+			name:        "an event for the same kill is merged",
+			events:      []corev1.Event{event("OOMKilled", "web-1", "app", killedAt.Add(30*time.Second))},
+			wantSources: []string{oomSourceBoth},
+		},
+		{
+			name:        "an event for an earlier kill",
+			events:      []corev1.Event{event("OOMKilled", "web-1", "app", killedAt.Add(-time.Hour))},
+			wantSources: []string{oomSourceStatus, oomSourceEvent},
+		},
+		{
+			name:        "an event for another container",
+			events:      []corev1.Event{event("OOMKilled", "web-1", "sidecar", killedAt.Add(-30*time.Second))},
+			wantSources: []string{oomSourceStatus, oomSourceEvent},
+		},
+		{
+			name:        "an event for another pod",
+			events:      []corev1.Event{event("OOMKilled", "web-2", "app", killedAt.Add(-30*time.Second))},
+			wantSources: []string{oomSourceStatus, oomSourceEvent},
+		},
+		{
+			name:        "events that are not OOM kills",
+			events:      []corev1.Event{event("BackOff", "web-1", "app", killedAt)},
+			wantSources: []string{oomSourceStatus},
+		},
+	}
+
+	a := NewAnalyzer(nil, nil, DefaultConfig())
+	pods := []corev1.Pod{pod}
+	workloads := newWorkloadResolver(&ClusterData{Pods: pods})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kills := a.analyzeOOMEvents(tt.events, pods, nil, workloads)
+			var sources []string
+			for _, kill := range kills {
+				sources = append(sources, kill.Source)
+			}
+			if !reflect.DeepEqual(sources, tt.wantSources) {
+				t.Errorf("sources = %v, want %v", sources, tt.wantSources)
+			}
+		})
+	}
+}
+
+func TestOOMKillsRequireEvents(t *testing.T) {
+	data, err := ReadSnapshot("testdata/cluster.snapshot.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data.CollectionErrors = append(data.CollectionErrors, CollectionError{Source: sourceEvents, Reason: reasonForbidden})
+	analysis := NewAnalyzer(nil, nil, DefaultConfig()).AnalyzeCluster(data)
+
+	skipped := false
+	for _, s := range analysis.SkippedAnalyses {
+		if s.Check == "oom-kills" && s.Source == sourceEvents {
+			skipped = true
+		}
+	}
+	if !skipped {
+		t.Errorf("skipped analyses = %+v, want oom-kills for missing events", analysis.SkippedAnalyses)
+	}
+	if len(analysis.OOMEvents) != 0 {
+		t.Errorf("OOM events = %+v, want none without events", analysis.OOMEvents)
+	}
+}
+
+func TestGenerateCriticalIssues(t *testing.T) {
+	node := func(ruleID, name string) Finding {
+		return newFinding(ruleID, objectRef("Node", "", name), "", "Node "+name, nil)
+	}
+	RegisterRule(Rule{ID: "test/critical", Category: "test", Severity: severityCritical, Title: "Test critical rule", Remediation: "Fix it"})
+	defer delete(rules, "test/critical")
+
+	findings := []Finding{
+		node(ruleNodeNotReady, "n1"),
+		newFinding("test/critical", objectRef("Node", "", "n1"), "", "critical", nil),
+		node(ruleNodeHighMemoryRequests, "n2"),
+		node(ruleNodeHighMemoryRequests, "n3"),
+		node(ruleNodeCordoned, "n4"),
+		newFinding(ruleMissingLimits, objectRef("Deployment", "app", "web"), "main", "no limits", nil),
+		newFinding(ruleWarningEvent, objectRef("Deployment", "app", "web"), "BackOff", "back-off", nil),
+	}
+
+	a := NewAnalyzer(nil, nil, DefaultConfig())
+	issues := a.generateCriticalIssues(&Analysis{Findings: findings})
+
+	type issue struct {
+		rule     string
+		priority int
+		examples int
+	}
+	want := []issue{
+		{ruleNodeNotReady, 1, 1},
+		{"test/critical", 1, 1},
+		{ruleMissingLimits, 2, 1},
+		{ruleNodeHighMemoryRequests, 3, 2},
+		{ruleNodeCordoned, 4, 1},
+	}
+	var got []issue
+	for _, i := range issues {
+		got = append(got, issue{i.RuleID, i.Priority, len(i.Examples)})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("critical issues = %+v, want %+v", got, want)
+	}
+}
+
+func TestRabbitMQAndJobFindingObjects(t *testing.T) {
+	controller := true
+	owned := func(name, kind, owner string) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "mq",
+			OwnerReferences: []metav1.OwnerReference{{Kind: kind, Name: owner, Controller: &controller}},
+		}}
+	}
+	started := metav1.NewTime(time.Date(2026, 10, 1, 11, 0, 0, 0, time.UTC))
+	shortJob := func(name, job string) corev1.Pod {
+		pod := owned(name, "Job", job)
+		pod.Status.Phase = corev1.PodSucceeded
+		pod.Status.StartTime = &started
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{State: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{FinishedAt: metav1.NewTime(started.Add(30 * time.Second))},
+		}}}
+		return pod
+	}
+
+	data := &ClusterData{Jobs: []batchv1.Job{{ObjectMeta: metav1.ObjectMeta{
+		Name: "sync-2901", Namespace: "mq", OwnerReferences: controllerRef("CronJob", "sync"),
+	}}}}
+	pods := []corev1.Pod{
+		owned("rabbitmq-0", "StatefulSet", "rabbitmq"),
+		owned("rabbitmq-1", "StatefulSet", "rabbitmq"),
+		shortJob("sync-2901-abcde", "sync-2901"),
+		shortJob("sync-2901-fghij", "sync-2901"),
+	}
+
+	a := NewAnalyzer(nil, nil, DefaultConfig())
+	workloads := newWorkloadResolver(data)
+	var ids []string
+	for _, f := range rabbitMQFindings(a.analyzeRabbitMQ(pods, workloads)) {
+		ids = append(ids, f.ID)
+	}
+	for _, f := range jobFindings(a.analyzeJobs(pods, workloads), 2) {
+		ids = append(ids, f.ID)
+	}
+
+	want := []string{
+		"stability/rabbitmq-priority-class:StatefulSet/mq/rabbitmq",
+		"stability/rabbitmq-memory-limits:StatefulSet/mq/rabbitmq",
+		"stability/short-lived-jobs:CronJob/mq/sync",
+	}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("finding IDs = %v, want %v", ids, want)
+	}
+}
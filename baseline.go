@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Finding statuses against a baseline report.
+const (
+	findingNew        = "new"
+	findingPersisting = "persisting"
+	findingResolved   = "resolved"
+)
+
+// BaselineComparison compares the findings of this run with those of an
+// earlier JSON or YAML report, matching them by ID.
+type BaselineComparison struct {
+	File        string    `json:"file"`
+	CollectedAt time.Time `json:"collectedAt"` // when the baseline's data was collected
+	New         []Finding `json:"new"`         // findings not in the baseline, most severe first
+	Persisting  int       `json:"persisting"`  // findings also in the baseline
+	Resolved    []Finding `json:"resolved"`    // baseline findings no longer found, most severe first
+}
+
+// LoadBaseline reads a report to compare findings with. Reports written
+// before findings were introduced cannot be used.
+func LoadBaseline(file string) (*ReportDocument, error) {
+	doc, err := ReadReportDocument(file)
+	if err != nil {
+		return nil, err
+	}
+	if doc.Analysis.Findings == nil {
+		return nil, fmt.Errorf("report %s has no findings; write a new baseline with -format=json or -format=yaml", file)
+	}
+	return doc, nil
+}
+
+// compareBaseline marks each finding as new or persisting and collects the
+// baseline findings that were resolved. New findings are moved to the front.
+// Baseline findings of checks that did not run, or that are now waived, are
+// not counted as resolved.
+func compareBaseline(analysis *Analysis, baseline *ReportDocument, file string, ran map[string]bool) *BaselineComparison {
+	previous := make(map[string]bool)
+	for _, f := range baseline.Analysis.Findings {
+		previous[f.ID] = true
+	}
+
+	comparison := &BaselineComparison{
+		File:        file,
+		CollectedAt: baseline.Cluster.CollectedAt,
+		New:         []Finding{},
+		Resolved:    []Finding{},
+	}
+
+	current := make(map[string]bool)
+	for i := range analysis.Findings {
+		f := &analysis.Findings[i]
+		current[f.ID] = true
+		if previous[f.ID] {
+			f.Status = findingPersisting
+			comparison.Persisting++
+		} else {
+			f.Status = findingNew
+			comparison.New = append(comparison.New, *f)
+		}
+	}
+	for _, s := range analysis.SuppressedFindings {
+		current[s.ID] = true
+	}
+
+	for _, f := range baseline.Analysis.Findings {
+		if current[f.ID] || (f.Check != "" && !ran[f.Check]) {
+			continue
+		}
+		f.Status = findingResolved
+		comparison.Resolved = append(comparison.Resolved, f)
+	}
+	sortFindings(comparison.Resolved)
+
+	sort.SliceStable(analysis.Findings, func(i, j int) bool {
+		return analysis.Findings[i].Status == findingNew && analysis.Findings[j].Status != findingNew
+	})
+	return comparison
+}
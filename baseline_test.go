@@ -0,0 +1,116 @@
+package main
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestCompareBaseline(t *testing.T) {
+	finding := func(id, severity, check string) Finding {
+		return Finding{ID: id, Severity: severity, Check: check}
+	}
+	ids := func(findings []Finding) []string {
+		out := []string{}
+		for _, f := range findings {
+			out = append(out, f.ID)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name           string
+		baseline       []Finding
+		current        []Finding
+		suppressed     []string
+		ran            []string
+		wantNew        []string
+		wantPersisting int
+		wantResolved   []string
+		wantOrder      []string // IDs of the findings after the comparison
+	}{
+		{
+			name:           "same findings",
+			baseline:       []Finding{finding("a", severityHigh, "c")},
+			current:        []Finding{finding("a", severityHigh, "c")},
+			ran:            []string{"c"},
+			wantNew:        []string{},
+			wantResolved:   []string{},
+			wantOrder:      []string{"a"},
+			wantPersisting: 1,
+		},
+		{
+			name:           "new findings lead, most severe first",
+			baseline:       []Finding{finding("a", severityHigh, "c")},
+			current:        []Finding{finding("a", severityHigh, "c"), finding("b", severityMedium, "c"), finding("d", severityLow, "c")},
+			ran:            []string{"c"},
+			wantNew:        []string{"b", "d"},
+			wantResolved:   []string{},
+			wantOrder:      []string{"b", "d", "a"},
+			wantPersisting: 1,
+		},
+		{
+			name:         "resolved findings, most severe first",
+			baseline:     []Finding{finding("a", severityLow, "c"), finding("b", severityCritical, "c")},
+			ran:          []string{"c"},
+			wantNew:      []string{},
+			wantResolved: []string{"b", "a"},
+			wantOrder:    []string{},
+		},
+		{
+			name:         "findings of checks that did not run are not resolved",
+			baseline:     []Finding{finding("a", severityHigh, "velero-backups")},
+			ran:          []string{"c"},
+			wantNew:      []string{},
+			wantResolved: []string{},
+			wantOrder:    []string{},
+		},
+		{
+			name:         "waived findings are not resolved",
+			baseline:     []Finding{finding("a", severityHigh, "c")},
+			suppressed:   []string{"a"},
+			ran:          []string{"c"},
+			wantNew:      []string{},
+			wantResolved: []string{},
+			wantOrder:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := &Analysis{Findings: append([]Finding{}, tt.current...)}
+			for _, id := range tt.suppressed {
+				analysis.SuppressedFindings = append(analysis.SuppressedFindings, SuppressedFinding{Finding: Finding{ID: id}})
+			}
+			ran := make(map[string]bool)
+			for _, id := range tt.ran {
+				ran[id] = true
+			}
+			baseline := &ReportDocument{Analysis: &Analysis{Findings: tt.baseline}}
+
+			comparison := compareBaseline(analysis, baseline, "baseline.json", ran)
+
+			if got := ids(comparison.New); !reflect.DeepEqual(got, tt.wantNew) {
+				t.Errorf("new = %v, want %v", got, tt.wantNew)
+			}
+			if comparison.Persisting != tt.wantPersisting {
+				t.Errorf("persisting = %d, want %d", comparison.Persisting, tt.wantPersisting)
+			}
+			if got := ids(comparison.Resolved); !reflect.DeepEqual(got, tt.wantResolved) {
+				t.Errorf("resolved = %v, want %v", got, tt.wantResolved)
+			}
+			if got := ids(analysis.Findings); !reflect.DeepEqual(got, tt.wantOrder) {
+				t.Errorf("findings = %v, want %v", got, tt.wantOrder)
+			}
+			for _, f := range analysis.Findings {
+				want := findingPersisting
+				if slices.Contains(tt.wantNew, f.ID) {
+					want = findingNew
+				}
+				if f.Status != want {
+					t.Errorf("finding %s status = %q, want %q", f.ID, f.Status, want)
+				}
+			}
+		})
+	}
+}
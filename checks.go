@@ -0,0 +1,309 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Check is one analysis of the collected cluster data. Built-in checks also
+// fill in their section of the Analysis; other checks only return findings.
+//
+// Checks are registered with RegisterCheck, so a check of your own, e.g. for
+// in-house custom resources, goes in its own file:
+//
+//	func init() {
+//		RegisterRule(Rule{ID: "acme/widget-unowned", Category: "acme", Severity: "medium", Title: "Widget without an owner"})
+//		RegisterCheck(NewCheck("acme-widgets", "Widgets without an owner team",
+//			[]string{"acme.example.com/widgets"}, runWidgetCheck))
+//	}
+type Check interface {
+	// ID identifies the check in config files, flags and reports. It must
+	// not change once released.
+	ID() string
+	// Description says what the check looks for.
+	Description() string
+	// Requires lists the data sources the check reads. The check is skipped
+	// when one of them was not collected. A source that is not built in,
+	// written as "group/resource", is listed with the dynamic client in its
+	// preferred version when the check is enabled.
+	Requires() []string
+	// Run analyzes the input and returns the findings.
+	Run(in *CheckInput) []Finding
+}
+
+// CheckInput is the data checks run against. Checks run in registration
+// order and see the results of the checks before them in Analysis.
+type CheckInput struct {
+	Data      *ClusterData
+	Config    *Config
+	Analysis  *Analysis
+	Pods      []corev1.Pod   // pods in namespaces passing the filters
+	Events    []corev1.Event // events in namespaces passing the filters
+	Workloads *workloadResolver
+
+	analyzer    *Analyzer
+	check       Check
+	allocations []nodeAllocation
+}
+
+// Available reports whether a source was collected, recording the running
+// check as skipped for it when it was not. Checks that can do without a
+// source use it instead of listing the source in Requires.
+func (in *CheckInput) Available(source string) bool {
+	e := in.Data.collectionError(source)
+	if e == nil {
+		return true
+	}
+	in.Analysis.SkippedAnalyses = append(in.Analysis.SkippedAnalyses, SkippedAnalysis{
+		Check:    in.check.ID(),
+		Analysis: in.check.Description(),
+		Source:   source,
+		Reason:   e.Reason,
+	})
+	return false
+}
+
+// nodeAllocations returns the requests, limits and usage of every node,
+// computed once for all node checks. Namespace filters do not apply: a node's
+// allocation accounts for every pod scheduled on it.
+func (in *CheckInput) nodeAllocations() []nodeAllocation {
+	if in.allocations == nil {
+		in.allocations = nodeAllocations(in.Data.Nodes, in.Data.Pods, in.Data.NodeMetrics)
+	}
+	return in.allocations
+}
+
+// NewCheck creates a check from a function.
+func NewCheck(id, description string, requires []string, run func(in *CheckInput) []Finding) Check {
+	return funcCheck{id: id, description: description, requires: requires, run: run}
+}
+
+type funcCheck struct {
+	id          string
+	description string
+	requires    []string
+	run         func(in *CheckInput) []Finding
+}
+
+func (c funcCheck) ID() string                   { return c.id }
+func (c funcCheck) Description() string          { return c.description }
+func (c funcCheck) Requires() []string           { return c.requires }
+func (c funcCheck) Run(in *CheckInput) []Finding { return c.run(in) }
+
+// checks holds the registered checks in the order they run.
+var checks []Check
+
+// RegisterCheck adds a check to the registry. It panics on an empty or
+// duplicate ID, as registration happens at init time.
+func RegisterCheck(check Check) {
+	if check.ID() == "" {
+		panic("check registered without an ID")
+	}
+	if findCheck(check.ID()) != nil {
+		panic(fmt.Sprintf("check %q registered twice", check.ID()))
+	}
+	checks = append(checks, check)
+}
+
+// RegisterRule adds the rule of a check's findings. It panics on an empty or
+// duplicate ID.
+func RegisterRule(rule Rule) {
+	if rule.ID == "" {
+		panic("rule registered without an ID")
+	}
+	if _, ok := rules[rule.ID]; ok {
+		panic(fmt.Sprintf("rule %q registered twice", rule.ID))
+	}
+	rules[rule.ID] = rule
+}
+
+func findCheck(id string) Check {
+	for _, check := range checks {
+		if check.ID() == id {
+			return check
+		}
+	}
+	return nil
+}
+
+// enabledChecks returns the checks the configuration enables, in order.
+func (c ChecksConfig) enabledChecks() []Check {
+	var enabled []Check
+	for _, check := range checks {
+		if c.Enabled(check.ID()) {
+			enabled = append(enabled, check)
+		}
+	}
+	return enabled
+}
+
+// customSources returns the sources enabled checks require that are not
+// collected by default, sorted.
+func (c ChecksConfig) customSources() []string {
+	seen := make(map[string]bool)
+	var sources []string
+	for _, check := range c.enabledChecks() {
+		for _, source := range check.Requires() {
+			if !builtinSources[source] && !seen[source] {
+				seen[source] = true
+				sources = append(sources, source)
+			}
+		}
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+// runChecks runs the enabled checks, skipping those whose required sources
+// were not collected, and records the IDs of the disabled ones. It returns
+// the IDs of the checks that ran.
+func (a *Analyzer) runChecks(in *CheckInput) (ran map[string]bool) {
+	ran = make(map[string]bool)
+	for _, check := range checks {
+		if !a.config.Checks.Enabled(check.ID()) {
+			in.Analysis.DisabledChecks = append(in.Analysis.DisabledChecks, check.ID())
+			continue
+		}
+
+		in.check = check
+		runnable := true
+		for _, source := range check.Requires() {
+			// Record every missing source, not only the first
+			if !in.Available(source) {
+				runnable = false
+			}
+		}
+		if !runnable {
+			continue
+		}
+		for _, f := range check.Run(in) {
+			f.Check = check.ID()
+			in.Analysis.Findings = append(in.Analysis.Findings, f)
+		}
+		ran[check.ID()] = true
+	}
+	return ran
+}
+
+// Built-in checks, in the order they run.
+func init() {
+	RegisterCheck(NewCheck("resource-gaps", "Containers without resource requests or limits",
+		[]string{sourcePods},
+		func(in *CheckInput) []Finding {
+			in.Analysis.ResourceGaps = in.analyzer.analyzeResourceGaps(in.Pods, in.Workloads)
+			return resourceGapFindings(in.Analysis.ResourceGaps)
+		}))
+
+	RegisterCheck(NewCheck("node-requests", "Nodes with requests above the node thresholds",
+		[]string{sourceNodes, sourcePods},
+		func(in *CheckInput) []Finding {
+			in.Analysis.NodeIssues = in.analyzer.analyzeNodes(in.nodeAllocations())
+			return nodeIssueFindings(in.Analysis.NodeIssues)
+		}))
+
+	RegisterCheck(NewCheck("node-pools", "Node pool totals and nodes unbalanced within their pool",
+		[]string{sourceNodes, sourcePods},
+		func(in *CheckInput) []Finding {
+			in.Analysis.NodePools = in.analyzer.analyzeNodePools(in.nodeAllocations())
+			return nodePoolFindings(in.Analysis.NodePools)
+		}))
+
+	RegisterCheck(NewCheck("node-utilization", "Node usage against requests and limits",
+		[]string{sourceNodes, sourcePods, sourceNodeMetrics},
+		func(in *CheckInput) []Finding {
+			in.Analysis.NodeUtilization = in.analyzer.analyzeNodeUtilization(in.nodeAllocations())
+			return nodeUtilizationFindings(in.Analysis.NodeUtilization)
+		}))
+
+	RegisterCheck(NewCheck("node-conditions", "Node conditions, pressure, cordons and taints",
+		[]string{sourceNodes},
+		func(in *CheckInput) []Finding {
+			in.Analysis.NodeConditions = in.analyzer.analyzeNodeConditions(in.Data.Nodes)
+			return nodeConditionFindings(in.Analysis.NodeConditions)
+		}))
+
+	// OOM kills come from events and container statuses; events expire
+	// after about an hour, while the last termination state of a container
+	// is kept until it terminates again, so either source is enough
+	RegisterCheck(NewCheck("oom-kills", "Containers OOM killed, from events and container statuses",
+		[]string{sourcePods, sourceEvents},
+		func(in *CheckInput) []Finding {
+			in.Analysis.OOMEvents = in.analyzer.analyzeOOMEvents(in.Events, in.Pods, in.Data.PodMetrics, in.Workloads)
+			return oomFindings(in.Analysis.OOMEvents)
+		}))
+
+	// Without the oom-kills check the kill counts are zero
+	RegisterCheck(NewCheck("node-oom-risk", "Nodes ranked by OOM kill risk from memory limit overcommit",
+		[]string{sourceNodes, sourcePods},
+		func(in *CheckInput) []Finding {
+			in.Analysis.NodeOOMRisk = in.analyzer.analyzeNodeOOMRisk(in.nodeAllocations(), in.Analysis.OOMEvents)
+			return nodeOOMRiskFindings(in.Analysis.NodeOOMRisk)
+		}))
+
+	RegisterCheck(NewCheck("namespace-risk", "Namespaces with many pods without requests",
+		[]string{sourcePods},
+		func(in *CheckInput) []Finding {
+			// Fall back to the namespaces pods run in when the namespace
+			// list could not be collected
+			namespaces := in.Data.Namespaces
+			if in.Data.collectionError(sourceNamespaces) != nil {
+				namespaces = namespacesFromPods(in.Data.Pods)
+			}
+			in.Analysis.NamespaceAnalysis, in.Analysis.ExcludedNamespaces = in.analyzer.analyzeNamespaces(in.Pods, namespaces, in.Workloads)
+			return namespaceFindings(in.Analysis.NamespaceAnalysis)
+		}))
+
+	RegisterCheck(NewCheck("rabbitmq", "RabbitMQ priority classes and memory limits",
+		[]string{sourcePods},
+		func(in *CheckInput) []Finding {
+			in.Analysis.RabbitMQFindings = in.analyzer.analyzeRabbitMQ(in.Pods, in.Workloads)
+			return rabbitMQFindings(in.Analysis.RabbitMQFindings)
+		}))
+
+	RegisterCheck(NewCheck("short-lived-jobs", "Jobs that finish within the short job duration",
+		[]string{sourcePods},
+		func(in *CheckInput) []Finding {
+			in.Analysis.ShortLivedJobs = in.analyzer.analyzeJobs(in.Pods, in.Workloads)
+			return jobFindings(in.Analysis.ShortLivedJobs, in.Config.Thresholds.ShortJobDuration)
+		}))
+
+	RegisterCheck(NewCheck("pod-restarts", "Containers restarting in the last 7 days",
+		[]string{sourcePods},
+		func(in *CheckInput) []Finding {
+			in.Analysis.PodRestarts = in.analyzer.analyzePodRestarts(in.Pods, in.Workloads, in.Data.CollectedAt)
+			return restartFindings(in.Analysis.PodRestarts)
+		}))
+
+	RegisterCheck(NewCheck("flux-events", "Flux warnings and errors",
+		[]string{sourceEvents},
+		func(in *CheckInput) []Finding {
+			in.Analysis.FluxEvents = in.analyzer.analyzeFluxEvents(in.Events, in.Data.CollectedAt)
+			return eventFindings(ruleFluxWarning, in.Analysis.FluxEvents.Last24Hours, in.Workloads)
+		}))
+
+	RegisterCheck(NewCheck("warning-events", "Warning events other than Flux",
+		[]string{sourceEvents},
+		func(in *CheckInput) []Finding {
+			in.Analysis.NonFluxEvents = in.analyzer.analyzeNonFluxEvents(in.Events, in.Data.CollectedAt)
+			return eventFindings(ruleWarningEvent, in.Analysis.NonFluxEvents.Last24Hours, in.Workloads)
+		}))
+
+	RegisterCheck(NewCheck("velero-backups", "Failed Velero backups",
+		[]string{sourceVeleroBackups},
+		func(in *CheckInput) []Finding {
+			in.Analysis.VeleroBackups = in.analyzer.analyzeVeleroBackups(in.Data.VeleroBackups, in.Data.CollectedAt)
+			return veleroFindings(in.Analysis.VeleroBackups)
+		}))
+}
+
+// checkIDs returns the IDs of the registered checks, for error messages.
+func checkIDs() string {
+	ids := make([]string, len(checks))
+	for i, check := range checks {
+		ids[i] = check.ID()
+	}
+	return strings.Join(ids, ", ")
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// command is a subcommand of the analyzer CLI.
+type command struct {
+	name        string
+	usage       string
+	description string
+	run         func(cmd *command, args []string) error
+}
+
+var commands = []*command{
+	{
+		name:        "analyze",
+		usage:       "[flags]",
+		description: "Collect cluster data, analyze it, add AI insights and write a report. This is the default command.",
+		run:         runAnalyzeCommand,
+	},
+	{
+		name:        "snapshot",
+		usage:       "[flags]",
+		description: "Capture cluster data to a compressed snapshot file for offline analysis.",
+		run:         runSnapshotCommand,
+	},
+	{
+		name:        "report",
+		usage:       "[flags]",
+		description: "Analyze a cluster or snapshot and write a report without calling the AI provider.",
+		run:         runReportCommand,
+	},
+	{
+		name:        "suggest",
+		usage:       "[flags]",
+		description: "Ask the AI provider for resource requests and limits for containers that are missing them.",
+		run:         runSuggestCommand,
+	},
+	{
+		name:        "diff",
+		usage:       "[flags] OLD NEW",
+		description: "Compare two JSON or YAML reports and show what changed between them.",
+		run:         runDiffCommand,
+	},
+	{
+		name:        "serve",
+		usage:       "[flags]",
+		description: "Re-analyze the cluster on an interval and serve the latest report over HTTP.",
+		run:         runServeCommand,
+	},
+	{
+		name:        "checks",
+		usage:       "list [flags]",
+		description: "List the registered checks, the data they require and whether the configuration enables them.",
+		run:         runChecksCommand,
+	},
+}
+
+// programName is how the binary was invoked, e.g. "kubectl analyze" when run as a kubectl plugin.
+func programName() string {
+	name := filepath.Base(os.Args[0])
+	name = strings.TrimSuffix(name, ".exe")
+	if plugin := strings.TrimPrefix(name, "kubectl-"); plugin != name {
+		return "kubectl " + strings.ReplaceAll(plugin, "_", "-")
+	}
+	return name
+}
+
+func findCommand(name string) *command {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+func printUsage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: %s <command> [flags]\n\n", programName())
+	fmt.Fprintln(out, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-10s %s\n", cmd.name, cmd.description)
+	}
+	fmt.Fprintf(out, "\nRun '%s <command> -h' for the flags of a command.\n", programName())
+}
+
+// newFlagSet creates the flag set for a subcommand with its help text.
+func (cmd *command) newFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet(cmd.name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s %s %s\n\n%s\n\nFlags:\n", programName(), cmd.name, cmd.usage, cmd.description)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// settingsFlags registers flags that override config file settings. Only flags
+// given on the command line are applied, so the precedence stays
+// defaults < config file < environment < explicit flags.
+type settingsFlags struct {
+	fs         *flag.FlagSet
+	configFile *string
+	apply      map[string]func(*Config)
+}
+
+func newSettingsFlags(fs *flag.FlagSet) *settingsFlags {
+	return &settingsFlags{
+		fs:         fs,
+		configFile: fs.String("config", os.Getenv("K8S_ANALYZER_CONFIG"), "path to a YAML config file (default: ./config.yaml if present)"),
+		apply:      make(map[string]func(*Config)),
+	}
+}
+
+func (s *settingsFlags) stringFlag(name, usage string, set func(*Config, string)) {
+	value := s.fs.String(name, "", usage)
+	s.apply[name] = func(c *Config) { set(c, *value) }
+}
+
+// connection registers the kubectl-style connection flags.
+func (s *settingsFlags) connection() *settingsFlags {
+	s.stringFlag("kubeconfig", "path to the kubeconfig file (default: $KUBECONFIG or ~/.kube/config)",
+		func(c *Config, v string) { c.Kubernetes.Kubeconfig = v })
+	s.stringFlag("context", "kubeconfig context to use (default: current context)",
+		func(c *Config, v string) { c.Kubernetes.Context = v })
+	s.stringFlag("cluster", "kubeconfig cluster to use",
+		func(c *Config, v string) { c.Kubernetes.Cluster = v })
+	s.stringFlag("user", "kubeconfig user to use",
+		func(c *Config, v string) { c.Kubernetes.User = v })
+	s.stringFlag("namespace", "comma-separated namespaces to analyze using namespaced access only (no cluster-wide lists or node analysis)",
+		func(c *Config, v string) { c.Kubernetes.Namespace = v })
+	s.stringFlag("server", "address and port of the Kubernetes API server",
+		func(c *Config, v string) { c.Kubernetes.Server = v })
+	s.stringFlag("as", "username to impersonate for the operation",
+		func(c *Config, v string) { c.Kubernetes.As = v })
+	s.stringFlag("request-timeout", "time to wait for a single API request, e.g. 30s (default: no timeout)",
+		func(c *Config, v string) { c.Kubernetes.RequestTimeout = v })
+
+	var groups stringList
+	s.fs.Var(&groups, "as-group", "group to impersonate for the operation (repeatable)")
+	s.apply["as-group"] = func(c *Config) { c.Kubernetes.AsGroups = groups }
+
+	defaults := DefaultConfig()
+	qps := s.fs.Float64("qps", float64(defaults.Kubernetes.QPS), "maximum API requests per second")
+	burst := s.fs.Int("burst", defaults.Kubernetes.Burst, "maximum burst of API requests above -qps")
+	pageSize := s.fs.Int64("page-size", defaults.Kubernetes.PageSize, "items fetched per List request (0 disables pagination)")
+	s.apply["qps"] = func(c *Config) { c.Kubernetes.QPS = float32(*qps) }
+	s.apply["burst"] = func(c *Config) { c.Kubernetes.Burst = *burst }
+	s.apply["page-size"] = func(c *Config) { c.Kubernetes.PageSize = *pageSize }
+	return s
+}
+
+// output registers the report file and format flags.
+func (s *settingsFlags) output(fileUsage string) *settingsFlags {
+	defaults := DefaultConfig()
+	file := s.fs.String("output", "", fileUsage)
+	format := s.fs.String("format", defaults.Output.Format, "report format (markdown, html, json or yaml)")
+	s.apply["output"] = func(c *Config) { c.Output.File = *file }
+	s.apply["format"] = func(c *Config) { c.Output.Format = *format }
+	return s
+}
+
+// ai registers the AI provider flags.
+func (s *settingsFlags) ai() *settingsFlags {
+	defaults := DefaultConfig()
+	provider := s.fs.String("ai-provider", defaults.AI.Provider, "AI provider (openai or azure)")
+	endpoint := s.fs.String("ai-endpoint", "", "AI endpoint URL (for Azure OpenAI)")
+	model := s.fs.String("ai-model", defaults.AI.Model, "AI model to use (gpt-4o, gpt-4o-mini, gpt-4-turbo, etc.)")
+	temperature := s.fs.Float64("ai-temperature", float64(defaults.AI.Temperature), "AI sampling temperature (0.0-2.0)")
+	maxTokens := s.fs.Int("ai-max-tokens", defaults.AI.MaxTokens, "maximum tokens for the AI analysis response")
+	s.apply["ai-provider"] = func(c *Config) { c.AI.Provider = *provider }
+	s.apply["ai-endpoint"] = func(c *Config) { c.AI.Endpoint = *endpoint }
+	s.apply["ai-model"] = func(c *Config) { c.AI.Model = *model }
+	s.apply["ai-temperature"] = func(c *Config) { c.AI.Temperature = float32(*temperature) }
+	s.apply["ai-max-tokens"] = func(c *Config) { c.AI.MaxTokens = *maxTokens }
+	return s
+}
+
+// checks registers the flags that select the checks to run.
+func (s *settingsFlags) checks() *settingsFlags {
+	s.stringFlag("checks", "comma-separated IDs of the only checks to run (see the checks list command)",
+		func(c *Config, v string) { c.Checks.Only = splitList(v) })
+	s.stringFlag("disable-checks", "comma-separated IDs of checks not to run",
+		func(c *Config, v string) { c.Checks.Disabled = splitList(v) })
+	s.stringFlag("waivers", "YAML file of waivers accepting findings with a reason, owner and expiry",
+		func(c *Config, v string) { c.Waivers.File = v })
+	s.stringFlag("baseline", "earlier JSON or YAML report to compare findings with, marking them new, persisting or resolved",
+		func(c *Config, v string) { c.Baseline.File = v })
+	return s
+}
+
+// load reads the config file and applies the environment and explicitly set flags.
+// It must be called after the flag set has been parsed.
+func (s *settingsFlags) load() (*Config, error) {
+	cfg, err := LoadConfig(*s.configFile)
+	if err != nil {
+		return nil, fmt.Errorf("Error loading config: %w", err)
+	}
+	cfg.ApplyEnv()
+
+	s.fs.Visit(func(f *flag.Flag) {
+		if apply, ok := s.apply[f.Name]; ok {
+			apply(cfg)
+		}
+	})
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("Invalid configuration: %w", err)
+	}
+
+	if cfg.Waivers.File != "" {
+		cfg.Waivers.entries, err = LoadWaivers(cfg.Waivers.File)
+		if err != nil {
+			return nil, fmt.Errorf("Error loading waivers: %w", err)
+		}
+	}
+
+	if cfg.Baseline.File != "" {
+		cfg.Baseline.report, err = LoadBaseline(cfg.Baseline.File)
+		if err != nil {
+			return nil, fmt.Errorf("Error loading baseline: %w", err)
+		}
+	}
+
+	// Namespace scope narrows the analysis the same way an include filter does
+	if namespaces := cfg.Kubernetes.Namespaces(); len(namespaces) > 0 {
+		cfg.Filters.IncludeNamespaces = namespaces
+		cfg.Filters.AppNamespacesOnly = false
+	}
+
+	return cfg, nil
+}
+
+// stringList is a repeatable string flag.
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
@@ -0,0 +1,351 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Data sources collected from the cluster, as named in CollectionError.
+const (
+	sourcePods          = "pods"
+	sourceNodes         = "nodes"
+	sourceEvents        = "events"
+	sourceNamespaces    = "namespaces"
+	sourcePodMetrics    = "metrics.k8s.io/pods"
+	sourceNodeMetrics   = "metrics.k8s.io/nodes"
+	sourceVeleroBackups = "velero.io/backups"
+	sourceReplicaSets   = "apps/replicasets"
+	sourceJobs          = "batch/jobs"
+)
+
+// builtinSources are the sources collected for every run. Checks may require
+// other sources, which are collected as custom resources.
+var builtinSources = map[string]bool{
+	sourcePods:          true,
+	sourceNodes:         true,
+	sourceEvents:        true,
+	sourceNamespaces:    true,
+	sourcePodMetrics:    true,
+	sourceNodeMetrics:   true,
+	sourceVeleroBackups: true,
+	sourceReplicaSets:   true,
+	sourceJobs:          true,
+}
+
+// Reasons a data source was not collected.
+const (
+	reasonForbidden    = "forbidden"     // RBAC does not allow listing the resource
+	reasonNotInstalled = "not-installed" // the API group is not served, e.g. Velero or metrics-server is absent
+	reasonError        = "error"         // the List call failed for another reason
+	reasonOutOfScope   = "out-of-scope"  // cluster-scoped data is not collected in namespace-scoped mode
+)
+
+// CollectionError records a data source that could not be collected, so
+// reports can state their gaps instead of silently showing empty sections.
+type CollectionError struct {
+	Source string `json:"source"`
+	Reason string `json:"reason"`
+	Error  string `json:"error"`
+}
+
+// SourceCoverage summarizes what was collected for one data source.
+type SourceCoverage struct {
+	Source string `json:"source"`
+	Status string `json:"status"` // "collected" or one of the CollectionError reasons
+	Items  int    `json:"items"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// SkippedAnalysis is an analysis that did not run because its input was not collected.
+type SkippedAnalysis struct {
+	Check    string `json:"check"`
+	Analysis string `json:"analysis"`
+	Source   string `json:"source"`
+	Reason   string `json:"reason"`
+}
+
+// collector runs independent List calls concurrently and records failures.
+type collector struct {
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+	errors []CollectionError
+}
+
+// run collects a source in the background. A NotFound error from a List call
+// means the API group is not served; sources must not return NotFound for a
+// missing object, as it would be reported as not installed.
+func (c *collector) run(source string, fn func() error) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		if err := fn(); err != nil {
+			reason := reasonError
+			switch {
+			case apierrors.IsForbidden(err):
+				reason = reasonForbidden
+			case apierrors.IsNotFound(err):
+				reason = reasonNotInstalled
+			}
+
+			c.mu.Lock()
+			c.errors = append(c.errors, CollectionError{Source: source, Reason: reason, Error: err.Error()})
+			c.mu.Unlock()
+		}
+	}()
+}
+
+// skip records a source that is deliberately not collected.
+func (c *collector) skip(source, reason, detail string) {
+	c.mu.Lock()
+	c.errors = append(c.errors, CollectionError{Source: source, Reason: reason, Error: detail})
+	c.mu.Unlock()
+}
+
+// wait blocks until every source is done and returns the failures sorted by source.
+func (c *collector) wait() []CollectionError {
+	c.wg.Wait()
+	sort.Slice(c.errors, func(i, j int) bool { return c.errors[i].Source < c.errors[j].Source })
+	return c.errors
+}
+
+// listPaged fetches a list in pages of pageSize items, following continue
+// tokens. A pageSize of 0 lists everything in one request. If a continue token
+// expires part-way, the list is restarted as a single unpaginated request, as
+// client-go's pager does.
+func listPaged[T any](ctx context.Context, pageSize int64, list func(context.Context, metav1.ListOptions) ([]T, string, error)) ([]T, error) {
+	var items []T
+	opts := metav1.ListOptions{Limit: pageSize}
+
+	for {
+		page, next, err := list(ctx, opts)
+		if err != nil {
+			if apierrors.IsResourceExpired(err) && opts.Continue != "" {
+				items, _, err = list(ctx, metav1.ListOptions{})
+				return items, err
+			}
+			return nil, err
+		}
+
+		items = append(items, page...)
+		if next == "" {
+			return items, nil
+		}
+		opts.Continue = next
+	}
+}
+
+// listScoped lists a namespaced resource cluster-wide, or in each namespace the
+// analysis is scoped to, after an RBAC preflight for every List call. Users
+// with access to only some namespaces can still be analyzed this way.
+func listScoped[T any](ctx context.Context, a *Analyzer, group, resource string, list func(namespace string) func(context.Context, metav1.ListOptions) ([]T, string, error)) ([]T, error) {
+	namespaces := a.config.Kubernetes.Namespaces()
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	var items []T
+	for _, ns := range namespaces {
+		if err := a.canList(ctx, ns, group, resource); err != nil {
+			return nil, err
+		}
+		page, err := listPaged(ctx, a.config.Kubernetes.PageSize, list(ns))
+		if err != nil {
+			if ns != metav1.NamespaceAll {
+				return nil, fmt.Errorf("namespace %s: %w", ns, err)
+			}
+			return nil, err
+		}
+		items = append(items, page...)
+	}
+	return items, nil
+}
+
+// canList runs a SelfSubjectAccessReview for listing a resource, cluster-wide
+// when namespace is empty. A denial is returned as a Forbidden error so the
+// List call can be skipped. If the review itself cannot be created, nil is
+// returned and the List call decides.
+func (a *Analyzer) canList(ctx context.Context, namespace, group, resource string) error {
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "list",
+				Group:     group,
+				Resource:  resource,
+			},
+		},
+	}
+
+	result, err := a.clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil || result.Status.Allowed {
+		return nil
+	}
+
+	scope := "at cluster scope"
+	if namespace != "" {
+		scope = fmt.Sprintf("in namespace %q", namespace)
+	}
+	detail := fmt.Sprintf("RBAC preflight: not allowed to list %s", scope)
+	if result.Status.Reason != "" {
+		detail += " (" + result.Status.Reason + ")"
+	}
+	return apierrors.NewForbidden(schema.GroupResource{Group: group, Resource: resource}, "", fmt.Errorf("%s", detail))
+}
+
+// collectCustomResources lists a source required by a check, written as
+// "group/resource" or as a core resource name, in the preferred version of
+// its group. A group the API server does not serve is reported as NotFound.
+func (a *Analyzer) collectCustomResources(ctx context.Context, source string) ([]unstructured.Unstructured, error) {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: source}
+	if group, resource, ok := strings.Cut(source, "/"); ok {
+		gvr = schema.GroupVersionResource{Group: group, Resource: resource}
+		groups, err := a.clientset.Discovery().ServerGroups()
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range groups.Groups {
+			if g.Name == group {
+				gvr.Version = g.PreferredVersion.Version
+			}
+		}
+		if gvr.Version == "" {
+			return nil, apierrors.NewNotFound(gvr.GroupResource(), "")
+		}
+	}
+
+	return listScoped(ctx, a, gvr.Group, gvr.Resource, func(ns string) func(context.Context, metav1.ListOptions) ([]unstructured.Unstructured, string, error) {
+		return func(ctx context.Context, opts metav1.ListOptions) ([]unstructured.Unstructured, string, error) {
+			list, err := a.dynamicClient.Resource(gvr).Namespace(ns).List(ctx, opts)
+			if err != nil {
+				return nil, "", err
+			}
+			for i := range list.Items {
+				list.Items[i].SetManagedFields(nil)
+			}
+			return list.Items, list.GetContinue(), nil
+		}
+	})
+}
+
+// collectionError returns the recorded failure for a source, or nil if it was collected.
+func (d *ClusterData) collectionError(source string) *CollectionError {
+	for i := range d.CollectionErrors {
+		if d.CollectionErrors[i].Source == source {
+			return &d.CollectionErrors[i]
+		}
+	}
+	return nil
+}
+
+// DataGaps returns the collection failures that make the report incomplete.
+// Optional components that are not installed and cluster-scoped data skipped
+// in namespace-scoped mode are not gaps.
+func (d *ClusterData) DataGaps() []CollectionError {
+	var gaps []CollectionError
+	for _, e := range d.CollectionErrors {
+		if e.Reason != reasonNotInstalled && e.Reason != reasonOutOfScope {
+			gaps = append(gaps, e)
+		}
+	}
+	return gaps
+}
+
+// Coverage lists every data source with its collection status and item count.
+func (d *ClusterData) Coverage() []SourceCoverage {
+	sources := []struct {
+		name  string
+		items int
+	}{
+		{sourcePods, len(d.Pods)},
+		{sourceNodes, len(d.Nodes)},
+		{sourceEvents, len(d.Events)},
+		{sourceNamespaces, len(d.Namespaces)},
+		{sourcePodMetrics, len(d.PodMetrics)},
+		{sourceNodeMetrics, len(d.NodeMetrics)},
+		{sourceVeleroBackups, len(d.VeleroBackups)},
+		{sourceReplicaSets, len(d.ReplicaSets)},
+		{sourceJobs, len(d.Jobs)},
+	}
+
+	// Sources required by checks, collected or not
+	custom := make(map[string]bool)
+	for source := range d.CustomResources {
+		custom[source] = true
+	}
+	for _, e := range d.CollectionErrors {
+		if !builtinSources[e.Source] {
+			custom[e.Source] = true
+		}
+	}
+	names := make([]string, 0, len(custom))
+	for source := range custom {
+		names = append(names, source)
+	}
+	sort.Strings(names)
+	for _, source := range names {
+		sources = append(sources, struct {
+			name  string
+			items int
+		}{source, len(d.CustomResources[source])})
+	}
+
+	coverage := make([]SourceCoverage, 0, len(sources))
+	for _, source := range sources {
+		row := SourceCoverage{Source: source.name, Status: "collected", Items: source.items}
+		if e := d.collectionError(source.name); e != nil {
+			row.Status = e.Reason
+			row.Detail = e.Error
+			if row.Status == "" {
+				// Snapshots taken before reasons were recorded
+				row.Status = reasonError
+			}
+		}
+		coverage = append(coverage, row)
+	}
+	return coverage
+}
+
+// namespacesFromPods returns a namespace object for every namespace that has pods.
+func namespacesFromPods(pods []corev1.Pod) []corev1.Namespace {
+	seen := make(map[string]bool)
+	var namespaces []corev1.Namespace
+	for _, pod := range pods {
+		if !seen[pod.Namespace] {
+			seen[pod.Namespace] = true
+			namespaces = append(namespaces, corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: pod.Namespace}})
+		}
+	}
+	return namespaces
+}
+
+// collectionFailed reports whether none of the core sources could be collected,
+// in which case there is nothing to analyze. For cluster-wide runs denied by
+// RBAC, the error suggests namespace-scoped mode.
+func collectionFailed(errors []CollectionError, scoped bool) error {
+	failed := make(map[string]CollectionError)
+	for _, e := range errors {
+		failed[e.Source] = e
+	}
+
+	for _, source := range []string{sourcePods, sourceNodes, sourceEvents, sourceNamespaces} {
+		if _, ok := failed[source]; !ok {
+			return nil
+		}
+	}
+
+	pods := failed[sourcePods]
+	if pods.Reason == reasonForbidden && !scoped {
+		return fmt.Errorf("unable to collect any cluster data: error listing pods: %s (use -namespace to analyze only namespaces you can access)", pods.Error)
+	}
+	return fmt.Errorf("unable to collect any cluster data: error listing pods: %s", pods.Error)
+}
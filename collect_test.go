@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fakeList serves items in pages of the requested limit, with the continue
+// token being the offset of the next page. expireAt makes the continue token
+// for that offset expire.
+type fakeList struct {
+	items    []int
+	expireAt string
+	fail     error
+	calls    []metav1.ListOptions
+}
+
+func (f *fakeList) list(_ context.Context, opts metav1.ListOptions) ([]int, string, error) {
+	f.calls = append(f.calls, opts)
+	if f.fail != nil {
+		return nil, "", f.fail
+	}
+	if opts.Continue != "" && opts.Continue == f.expireAt {
+		f.expireAt = ""
+		return nil, "", apierrors.NewResourceExpired("continue token expired")
+	}
+
+	start, _ := strconv.Atoi(opts.Continue)
+	end := len(f.items)
+	if opts.Limit > 0 && start+int(opts.Limit) < end {
+		end = start + int(opts.Limit)
+	}
+	next := ""
+	if end < len(f.items) {
+		next = strconv.Itoa(end)
+	}
+	return f.items[start:end], next, nil
+}
+
+func TestListPaged(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+	failure := errors.New("connection refused")
+
+	tests := []struct {
+		name      string
+		pageSize  int64
+		list      *fakeList
+		want      []int
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name:      "unpaginated",
+			list:      &fakeList{items: items},
+			want:      items,
+			wantCalls: 1,
+		},
+		{
+			name:      "pages are joined",
+			pageSize:  3,
+			list:      &fakeList{items: items},
+			want:      items,
+			wantCalls: 3,
+		},
+		{
+			name:      "an expired continue token restarts unpaginated",
+			pageSize:  3,
+			list:      &fakeList{items: items, expireAt: "6"},
+			want:      items,
+			wantCalls: 4,
+		},
+		{
+			name:      "an error fails the list",
+			pageSize:  3,
+			list:      &fakeList{items: items, fail: failure},
+			wantCalls: 1,
+			wantErr:   failure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := listPaged(context.Background(), tt.pageSize, tt.list.list)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("listPaged() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("listPaged() = %v, want %v", got, tt.want)
+			}
+			if len(tt.list.calls) != tt.wantCalls {
+				t.Errorf("made %d List calls, want %d", len(tt.list.calls), tt.wantCalls)
+			}
+			for _, opts := range tt.list.calls {
+				if opts.Continue == "" && opts.Limit != 0 && opts.Limit != tt.pageSize {
+					t.Errorf("List called with limit %d, want %d", opts.Limit, tt.pageSize)
+				}
+			}
+		})
+	}
+}
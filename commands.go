@@ -0,0 +1,335 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+func runAnalyzeCommand(cmd *command, args []string) error {
+	fs := cmd.newFlagSet()
+	settings := newSettingsFlags(fs).connection().output("output file path for the report (default: <cluster>-YYYYMMDD.<ext>)").ai().checks()
+	snapshotFile := fs.String("snapshot", "", "capture cluster data to this compressed snapshot file and exit without analyzing (same as the snapshot command)")
+	fromSnapshot := fs.String("from-snapshot", "", "analyze a previously captured snapshot file instead of a live cluster")
+	contexts := fs.String("contexts", "", "comma-separated kubeconfig contexts to analyze concurrently (multi-cluster mode)")
+	allContexts := fs.Bool("all-contexts", false, "analyze every context in the kubeconfig (multi-cluster mode)")
+	outputDir := fs.String("output-dir", ".", "directory for per-cluster reports in multi-cluster mode")
+	parallel := fs.Int("parallel", 4, "maximum number of clusters analyzed at the same time in multi-cluster mode")
+	fs.Parse(args)
+
+	if *snapshotFile != "" && *fromSnapshot != "" {
+		return fmt.Errorf("-snapshot and -from-snapshot cannot be used together")
+	}
+	fleetMode := *contexts != "" || *allContexts
+	if fleetMode && (*snapshotFile != "" || *fromSnapshot != "") {
+		return fmt.Errorf("-contexts/-all-contexts cannot be combined with -snapshot or -from-snapshot")
+	}
+
+	cfg, err := settings.load()
+	if err != nil {
+		return err
+	}
+	// A baseline is the report of one cluster, so it cannot be compared with
+	// every cluster of a fleet
+	if fleetMode && cfg.Baseline.File != "" {
+		return fmt.Errorf("-contexts/-all-contexts cannot be combined with -baseline or baseline.file")
+	}
+
+	ctx := context.Background()
+
+	if fleetMode {
+		return runFleetAnalysis(ctx, cfg, splitList(*contexts), *allContexts, *outputDir, *parallel)
+	}
+
+	out := progress{}
+	analyzer, data, err := loadClusterData(ctx, cfg, *fromSnapshot, out)
+	if err != nil {
+		return err
+	}
+
+	// Snapshot mode: persist the raw data and stop before analysis
+	if *snapshotFile != "" {
+		if err := WriteSnapshot(*snapshotFile, data); err != nil {
+			return fmt.Errorf("Error writing snapshot: %w", err)
+		}
+		fmt.Printf("💾 Snapshot saved to: %s\n", *snapshotFile)
+		return nil
+	}
+
+	analysis := runAnalysis(ctx, cfg, analyzer, data, newAIClientFromEnv(cfg, out), out)
+
+	outputFile := reportOutputPath(data, cfg)
+	if err := writeReport(outputFile, data, analysis, cfg, out); err != nil {
+		return err
+	}
+
+	fmt.Printf("✨ Analysis complete! Report saved to: %s\n", outputFile)
+	return nil
+}
+
+func runFleetAnalysis(ctx context.Context, cfg *Config, contextNames []string, allContexts bool, outputDir string, parallel int) error {
+	if allContexts {
+		var err error
+		contextNames, err = listContexts(cfg.Kubernetes.Kubeconfig)
+		if err != nil {
+			return fmt.Errorf("Error listing kubeconfig contexts: %w", err)
+		}
+	}
+	if len(contextNames) == 0 {
+		return fmt.Errorf("No kubeconfig contexts to analyze")
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("Error creating output directory: %w", err)
+	}
+
+	fmt.Printf("🌐 Analyzing %d clusters: %s\n", len(contextNames), strings.Join(contextNames, ", "))
+	results := RunFleet(ctx, cfg, contextNames, outputDir, parallel)
+
+	summaryFile := cfg.Output.File
+	if summaryFile == "" || summaryFile == "cluster-analysis-report.md" {
+		summaryFile = filepath.Join(outputDir, fmt.Sprintf("fleet-summary-%s.%s",
+			time.Now().Format("20060102"), fleetSummaryExtension(cfg.Output.Format)))
+	}
+
+	summary, err := RenderFleetSummary(results, cfg, summaryFile)
+	if err != nil {
+		return fmt.Errorf("Error generating fleet summary: %w", err)
+	}
+	if err := os.WriteFile(summaryFile, summary, 0644); err != nil {
+		return fmt.Errorf("Error writing fleet summary: %w", err)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	fmt.Printf("✨ Fleet analysis complete (%d/%d clusters succeeded). Summary saved to: %s\n",
+		len(results)-failed, len(results), summaryFile)
+	if failed == len(results) {
+		return fmt.Errorf("all %d clusters failed", failed)
+	}
+	return nil
+}
+
+func runSnapshotCommand(cmd *command, args []string) error {
+	fs := cmd.newFlagSet()
+	settings := newSettingsFlags(fs).connection()
+	outputFile := fs.String("output", "", "snapshot file to write (default: <cluster>-YYYYMMDD.json.gz)")
+	fs.Parse(args)
+
+	cfg, err := settings.load()
+	if err != nil {
+		return err
+	}
+
+	_, data, err := loadClusterData(context.Background(), cfg, "", progress{})
+	if err != nil {
+		return err
+	}
+
+	path := *outputFile
+	if path == "" {
+		path = autoFileName(data.ClusterName, data, "json.gz")
+	}
+
+	if err := WriteSnapshot(path, data); err != nil {
+		return fmt.Errorf("Error writing snapshot: %w", err)
+	}
+	fmt.Printf("💾 Snapshot saved to: %s\n", path)
+	return nil
+}
+
+func runReportCommand(cmd *command, args []string) error {
+	fs := cmd.newFlagSet()
+	settings := newSettingsFlags(fs).connection().output("output file path for the report (default: <cluster>-YYYYMMDD.<ext>)").checks()
+	fromSnapshot := fs.String("from-snapshot", "", "report on a previously captured snapshot file instead of a live cluster")
+	fs.Parse(args)
+
+	cfg, err := settings.load()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	out := progress{}
+	analyzer, data, err := loadClusterData(ctx, cfg, *fromSnapshot, out)
+	if err != nil {
+		return err
+	}
+
+	analysis := runAnalysis(ctx, cfg, analyzer, data, nil, out)
+
+	outputFile := reportOutputPath(data, cfg)
+	if err := writeReport(outputFile, data, analysis, cfg, out); err != nil {
+		return err
+	}
+
+	fmt.Printf("✨ Report saved to: %s\n", outputFile)
+	return nil
+}
+
+func runChecksCommand(cmd *command, args []string) error {
+	fs := cmd.newFlagSet()
+	settings := newSettingsFlags(fs).checks()
+	if len(args) == 0 || args[0] != "list" {
+		fs.Usage()
+		return fmt.Errorf("unknown checks subcommand (supported: list)")
+	}
+	fs.Parse(args[1:])
+
+	cfg, err := settings.load()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATUS\tREQUIRES\tDESCRIPTION")
+	for _, check := range checks {
+		status := "enabled"
+		if !cfg.Checks.Enabled(check.ID()) {
+			status = "disabled"
+		}
+		requires := strings.Join(check.Requires(), ",")
+		if requires == "" {
+			requires = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", check.ID(), status, requires, check.Description())
+	}
+	return w.Flush()
+}
+
+func runSuggestCommand(cmd *command, args []string) error {
+	fs := cmd.newFlagSet()
+	settings := newSettingsFlags(fs).connection().ai().output("file to write the suggestions to (default: stdout)")
+	fromSnapshot := fs.String("from-snapshot", "", "suggest resources for a previously captured snapshot file instead of a live cluster")
+	fs.Parse(args)
+
+	cfg, err := settings.load()
+	if err != nil {
+		return err
+	}
+	if cfg.Output.Format == "html" {
+		return fmt.Errorf("suggest supports markdown, json and yaml output")
+	}
+
+	apiKey := aiAPIKey()
+	if apiKey == "" {
+		return fmt.Errorf("suggest requires OPENAI_API_KEY or AZURE_OPENAI_API_KEY to be set")
+	}
+	aiClient, err := NewAIClient(apiKey, cfg)
+	if err != nil {
+		return fmt.Errorf("Error initializing AI client: %w", err)
+	}
+
+	// Suggestions may go to stdout, so keep progress on stderr
+	ctx := context.Background()
+	out := progress{w: os.Stderr}
+	_, data, err := loadClusterData(ctx, cfg, *fromSnapshot, out)
+	if err != nil {
+		return err
+	}
+
+	generateAISuggestions(ctx, cfg, data, aiClient, out)
+
+	rendered, err := renderSuggestions(data, cfg)
+	if err != nil {
+		return err
+	}
+
+	if cfg.Output.File == "" {
+		_, err := os.Stdout.Write(rendered)
+		return err
+	}
+	if err := os.WriteFile(cfg.Output.File, rendered, 0644); err != nil {
+		return fmt.Errorf("Error writing suggestions: %w", err)
+	}
+	out.Printf("✨ Suggestions saved to: %s\n", cfg.Output.File)
+	return nil
+}
+
+// renderSuggestions formats AI resource suggestions next to the current values.
+func renderSuggestions(data *ClusterData, cfg *Config) ([]byte, error) {
+	switch cfg.Output.Format {
+	case "json":
+		out, err := json.MarshalIndent(data.AISuggestions, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("error encoding JSON suggestions: %w", err)
+		}
+		return append(out, '\n'), nil
+	case "yaml":
+		out, err := yaml.Marshal(data.AISuggestions)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding YAML suggestions: %w", err)
+		}
+		return out, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# AI Resource Suggestions\n\n")
+	sb.WriteString(fmt.Sprintf("**Cluster:** `%s`\n\n", data.ClusterName))
+
+	if len(data.AISuggestions) == 0 {
+		sb.WriteString("No suggestions: all running containers have requests and limits configured.\n")
+		return []byte(sb.String()), nil
+	}
+
+	sb.WriteString("Suggested values are shown in **bold**; other values are the current configuration.\n\n")
+
+	inventory := make(map[string]PodResourceInfo)
+	for _, info := range mergeResourceInfo(buildPodInventory(data, cfg)) {
+		inventory[info.Namespace+"/"+suggestionKey(info.Workload, info.ContainerName)] = info
+	}
+
+	namespaces := make([]string, 0, len(data.AISuggestions))
+	for ns := range data.AISuggestions {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	for _, ns := range namespaces {
+		suggestions := data.AISuggestions[ns]
+		keys := make([]string, 0, len(suggestions))
+		for key := range suggestions {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		sb.WriteString(fmt.Sprintf("## Namespace: `%s`\n\n", ns))
+		sb.WriteString("| Workload | Replicas | Container | CPU Request | CPU Limit | Memory Request | Memory Limit |\n")
+		sb.WriteString("|----------|----------|-----------|-------------|-----------|----------------|--------------|\n")
+		for _, key := range keys {
+			s := suggestions[key]
+			current := inventory[ns+"/"+key]
+			sb.WriteString(fmt.Sprintf("| %s | %d | %s | %s | %s | %s | %s |\n",
+				s.Workload, current.Replicas, s.ContainerName,
+				suggestionValue(s.CPURequest, current.CPURequest),
+				suggestionValue(s.CPULimit, current.CPULimit),
+				suggestionValue(s.MemoryRequest, current.MemoryRequest),
+				suggestionValue(s.MemoryLimit, current.MemoryLimit)))
+		}
+		sb.WriteString("\n")
+	}
+
+	return []byte(sb.String()), nil
+}
+
+func suggestionValue(suggested, current string) string {
+	if suggested == "" || suggested == "KEEP" {
+		if current == "" {
+			return "-"
+		}
+		return current
+	}
+	return fmt.Sprintf("**`%s`**", suggested)
+}
@@ -0,0 +1,372 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// defaultConfigFile is picked up from the working directory when no --config flag is given.
+const defaultConfigFile = "config.yaml"
+
+type Config struct {
+	Kubernetes     KubernetesConfig     `json:"kubernetes"`
+	Output         OutputConfig         `json:"output"`
+	AI             AIConfig             `json:"ai"`
+	Thresholds     ThresholdsConfig     `json:"thresholds"`
+	Filters        FiltersConfig        `json:"filters"`
+	NodePools      NodePoolsConfig      `json:"node_pools"`
+	RabbitMQ       RabbitMQConfig       `json:"rabbitmq"`
+	Checks         ChecksConfig         `json:"checks"`
+	Waivers        WaiversConfig        `json:"waivers"`
+	Baseline       BaselineConfig       `json:"baseline"`
+	ReportSections ReportSectionsConfig `json:"report_sections"`
+}
+
+// KubernetesConfig mirrors the kubectl connection flags. Empty values fall back
+// to the kubeconfig, which is located with the standard loading rules ($KUBECONFIG,
+// then ~/.kube/config) unless Kubeconfig is set.
+type KubernetesConfig struct {
+	Kubeconfig     string   `json:"kubeconfig"`
+	Context        string   `json:"context"`
+	Cluster        string   `json:"cluster"`
+	User           string   `json:"user"`
+	Namespace      string   `json:"namespace"` // comma-separated namespaces; when set, only namespaced lists are used
+	Server         string   `json:"server"`
+	As             string   `json:"as"`
+	AsGroups       []string `json:"as_groups"`
+	RequestTimeout string   `json:"request_timeout"` // e.g. "30s"; "0" means no timeout
+	QPS            float32  `json:"qps"`             // client-side request rate limit
+	Burst          int      `json:"burst"`
+	PageSize       int64    `json:"page_size"` // items per List request; 0 disables pagination
+}
+
+type OutputConfig struct {
+	File   string `json:"file"`
+	Format string `json:"format"`
+}
+
+type AIConfig struct {
+	Provider    string  `json:"provider"`
+	Endpoint    string  `json:"endpoint"`
+	Model       string  `json:"model"`
+	Temperature float32 `json:"temperature"`
+	MaxTokens   int     `json:"max_tokens"`
+}
+
+type ThresholdsConfig struct {
+	NodeCPUThreshold    float64 `json:"node_cpu_threshold"`
+	NodeMemoryThreshold float64 `json:"node_memory_threshold"`
+	CriticalRisk        float64 `json:"critical_risk"`
+	HighRisk            float64 `json:"high_risk"`
+	MediumRisk          float64 `json:"medium_risk"`
+	ShortJobDuration    float64 `json:"short_job_duration"` // minutes
+	CriticalPriority    int32   `json:"critical_priority"`
+	NodePoolOutlier     float64 `json:"node_pool_outlier"` // percentage points a node may differ from its pool's mean
+}
+
+type FiltersConfig struct {
+	IncludeNamespaces []string             `json:"include_namespaces"`
+	ExcludeNamespaces []string             `json:"exclude_namespaces"`
+	AppNamespacesOnly bool                 `json:"app_namespaces_only"` // restrict the namespace risk analysis to AppNamespaces
+	AppNamespaces     AppNamespaceSelector `json:"app_namespaces"`
+}
+
+// AppNamespaceSelector selects the application namespaces for the namespace
+// risk analysis. Every criterion that is set must match.
+type AppNamespaceSelector struct {
+	NamePattern   string `json:"name_pattern"`   // regular expression matched against the namespace name
+	LabelSelector string `json:"label_selector"` // e.g. "tier=application,team!=platform"
+	Annotation    string `json:"annotation"`     // "key" or "key=value" the namespace must be annotated with
+}
+
+// NodePoolsConfig controls how nodes are grouped into pools.
+type NodePoolsConfig struct {
+	LabelKey string `json:"label_key"` // node label naming the pool, checked before the well-known ones
+}
+
+type RabbitMQConfig struct {
+	Keywords             []string                   `json:"keywords"`
+	RecommendedResources RecommendedResourcesConfig `json:"recommended_resources"`
+}
+
+type RecommendedResourcesConfig struct {
+	Requests ResourceValues `json:"requests"`
+	Limits   ResourceValues `json:"limits"`
+}
+
+type ResourceValues struct {
+	Memory string `json:"memory"`
+	CPU    string `json:"cpu"`
+}
+
+// ChecksConfig selects the checks that run, by ID. Run "checks list" for the IDs.
+type ChecksConfig struct {
+	Only     []string `json:"only"`     // when set, only these checks run
+	Disabled []string `json:"disabled"` // checks that do not run
+}
+
+// Enabled reports whether a check runs.
+func (c ChecksConfig) Enabled(id string) bool {
+	for _, disabled := range c.Disabled {
+		if disabled == id {
+			return false
+		}
+	}
+	if len(c.Only) == 0 {
+		return true
+	}
+	for _, only := range c.Only {
+		if only == id {
+			return true
+		}
+	}
+	return false
+}
+
+// WaiversConfig points to the central waivers file, which accepts findings
+// with a reason, owner and expiry date.
+type WaiversConfig struct {
+	File string `json:"file"`
+
+	entries []Waiver // loaded from File
+}
+
+// BaselineConfig points to an earlier JSON or YAML report whose findings the
+// run is compared with.
+type BaselineConfig struct {
+	File string `json:"file"`
+
+	report *ReportDocument // loaded from File
+}
+
+type ReportSectionsConfig struct {
+	ClusterHealth      bool `json:"cluster_health"`
+	CriticalIssues     bool `json:"critical_issues"`
+	ResourceManagement bool `json:"resource_management"`
+	NodeAnalysis       bool `json:"node_analysis"`
+	PodRestarts        bool `json:"pod_restarts"`
+	FluxEvents         bool `json:"flux_events"`
+	WarningEvents      bool `json:"warning_events"`
+	VeleroBackups      bool `json:"velero_backups"`
+	RabbitMQStability  bool `json:"rabbitmq_stability"`
+	NamespaceAnalysis  bool `json:"namespace_analysis"`
+	AIInsights         bool `json:"ai_insights"`
+	Appendix           bool `json:"appendix"`
+}
+
+// DefaultConfig returns the settings used when no config file is present.
+func DefaultConfig() *Config {
+	return &Config{
+		Kubernetes: KubernetesConfig{
+			QPS:      50,
+			Burst:    100,
+			PageSize: 500,
+		},
+		Output: OutputConfig{
+			Format: "markdown",
+		},
+		AI: AIConfig{
+			Provider:    "openai",
+			Model:       "gpt-4o",
+			Temperature: 0.7,
+			MaxTokens:   2000,
+		},
+		Thresholds: ThresholdsConfig{
+			NodeCPUThreshold:    80,
+			NodeMemoryThreshold: 80,
+			CriticalRisk:        75,
+			HighRisk:            50,
+			MediumRisk:          25,
+			ShortJobDuration:    2,
+			CriticalPriority:    1000000,
+			NodePoolOutlier:     25,
+		},
+		Filters: FiltersConfig{
+			AppNamespacesOnly: true,
+			AppNamespaces: AppNamespaceSelector{
+				NamePattern: "^[a-z0-9]{3}$",
+			},
+		},
+		RabbitMQ: RabbitMQConfig{
+			Keywords: []string{"rabbitmq", "rabbit"},
+			RecommendedResources: RecommendedResourcesConfig{
+				Requests: ResourceValues{Memory: "2Gi", CPU: "1000m"},
+				Limits:   ResourceValues{Memory: "4Gi", CPU: "2000m"},
+			},
+		},
+		ReportSections: ReportSectionsConfig{
+			ClusterHealth:      true,
+			CriticalIssues:     true,
+			ResourceManagement: true,
+			NodeAnalysis:       true,
+			PodRestarts:        true,
+			FluxEvents:         true,
+			WarningEvents:      true,
+			VeleroBackups:      true,
+			RabbitMQStability:  true,
+			NamespaceAnalysis:  true,
+			AIInsights:         true,
+			Appendix:           true,
+		},
+	}
+}
+
+// LoadConfig reads a YAML config file on top of DefaultConfig. An empty path
+// falls back to config.yaml in the working directory, if it exists.
+func LoadConfig(path string) (*Config, error) {
+	cfg := DefaultConfig()
+
+	explicit := path != ""
+	if !explicit {
+		path = defaultConfigFile
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("error reading config file %s: %w", path, err)
+	}
+
+	if err := yaml.UnmarshalStrict(content, cfg); err != nil {
+		return nil, fmt.Errorf("error parsing config file %s: %w", path, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// ApplyEnv overrides config values from environment variables.
+func (c *Config) ApplyEnv() {
+	if v := os.Getenv("AZURE_OPENAI_ENDPOINT"); v != "" {
+		c.AI.Endpoint = v
+	}
+	if v := os.Getenv("AI_MODEL"); v != "" {
+		c.AI.Model = v
+	}
+}
+
+// Validate checks that thresholds and settings are within sensible ranges.
+func (c *Config) Validate() error {
+	if c.AI.Provider != "openai" && c.AI.Provider != "azure" {
+		return fmt.Errorf("ai.provider must be \"openai\" or \"azure\", got %q", c.AI.Provider)
+	}
+	if c.AI.Temperature < 0 || c.AI.Temperature > 2 {
+		return fmt.Errorf("ai.temperature must be between 0 and 2, got %.2f", c.AI.Temperature)
+	}
+	if c.AI.MaxTokens <= 0 {
+		return fmt.Errorf("ai.max_tokens must be positive, got %d", c.AI.MaxTokens)
+	}
+
+	t := c.Thresholds
+	for name, value := range map[string]float64{
+		"node_cpu_threshold":    t.NodeCPUThreshold,
+		"node_memory_threshold": t.NodeMemoryThreshold,
+		"critical_risk":         t.CriticalRisk,
+		"high_risk":             t.HighRisk,
+		"medium_risk":           t.MediumRisk,
+		"node_pool_outlier":     t.NodePoolOutlier,
+	} {
+		if value < 0 || value > 100 {
+			return fmt.Errorf("thresholds.%s must be a percentage between 0 and 100, got %.1f", name, value)
+		}
+	}
+	if !(t.CriticalRisk >= t.HighRisk && t.HighRisk >= t.MediumRisk) {
+		return fmt.Errorf("thresholds must satisfy critical_risk >= high_risk >= medium_risk")
+	}
+	if t.ShortJobDuration <= 0 {
+		return fmt.Errorf("thresholds.short_job_duration must be positive, got %.1f", t.ShortJobDuration)
+	}
+
+	if _, err := parseRequestTimeout(c.Kubernetes.RequestTimeout); err != nil {
+		return fmt.Errorf("kubernetes.request_timeout: %w", err)
+	}
+	if c.Kubernetes.QPS <= 0 {
+		return fmt.Errorf("kubernetes.qps must be positive, got %.1f", c.Kubernetes.QPS)
+	}
+	if c.Kubernetes.Burst < 1 {
+		return fmt.Errorf("kubernetes.burst must be at least 1, got %d", c.Kubernetes.Burst)
+	}
+	if c.Kubernetes.PageSize < 0 {
+		return fmt.Errorf("kubernetes.page_size must not be negative, got %d", c.Kubernetes.PageSize)
+	}
+	if len(c.Kubernetes.AsGroups) > 0 && c.Kubernetes.As == "" {
+		return fmt.Errorf("kubernetes.as_groups requires kubernetes.as to be set")
+	}
+
+	if _, err := newNamespaceSelector(c.Filters); err != nil {
+		return fmt.Errorf("filters.app_namespaces.%w", err)
+	}
+
+	for name, ids := range map[string][]string{"only": c.Checks.Only, "disabled": c.Checks.Disabled} {
+		for _, id := range ids {
+			if findCheck(id) == nil {
+				return fmt.Errorf("checks.%s: unknown check %q (available: %s)", name, id, checkIDs())
+			}
+		}
+	}
+
+	switch c.Output.Format {
+	case "markdown", "html", "json", "yaml":
+	default:
+		return fmt.Errorf("output.format %q is not supported (supported: markdown, html, json, yaml)", c.Output.Format)
+	}
+
+	return nil
+}
+
+// parseRequestTimeout accepts the same values as kubectl's --request-timeout:
+// a duration such as "30s", or a bare number of seconds. Empty or zero means no timeout.
+func parseRequestTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, fmt.Errorf("timeout must not be negative, got %q", value)
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout %q (use a duration such as 30s or 2m)", value)
+	}
+	if timeout < 0 {
+		return 0, fmt.Errorf("timeout must not be negative, got %q", value)
+	}
+	return timeout, nil
+}
+
+// Namespaces returns the namespaces the analysis is scoped to, or nil for a
+// cluster-wide analysis.
+func (k KubernetesConfig) Namespaces() []string {
+	return splitList(k.Namespace)
+}
+
+// Includes reports whether a namespace passes the include/exclude filters.
+func (f FiltersConfig) Includes(namespace string) bool {
+	for _, ns := range f.ExcludeNamespaces {
+		if ns == namespace {
+			return false
+		}
+	}
+
+	if len(f.IncludeNamespaces) == 0 {
+		return true
+	}
+	for _, ns := range f.IncludeNamespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeConfig writes a config file in a temporary directory and returns its path.
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	defaults := DefaultConfig()
+
+	tests := []struct {
+		name      string
+		file      string // config file content; no file when empty
+		env       map[string]string
+		args      []string
+		wantModel string
+		wantCPU   float64
+	}{
+		{
+			name:      "defaults",
+			wantModel: defaults.AI.Model,
+			wantCPU:   defaults.Thresholds.NodeCPUThreshold,
+		},
+		{
+			name:      "file over defaults",
+			file:      "ai:\n  model: gpt-4o-mini\nthresholds:\n  node_cpu_threshold: 70\n",
+			wantModel: "gpt-4o-mini",
+			wantCPU:   70,
+		},
+		{
+			name:      "environment over file",
+			file:      "ai:\n  model: gpt-4o-mini\nthresholds:\n  node_cpu_threshold: 70\n",
+			env:       map[string]string{"AI_MODEL": "gpt-4-turbo"},
+			wantModel: "gpt-4-turbo",
+			wantCPU:   70,
+		},
+		{
+			name:      "flags over environment",
+			file:      "ai:\n  model: gpt-4o-mini\nthresholds:\n  node_cpu_threshold: 70\n",
+			env:       map[string]string{"AI_MODEL": "gpt-4-turbo"},
+			args:      []string{"-ai-model", "gpt-4o"},
+			wantModel: "gpt-4o",
+			wantCPU:   70,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("AI_MODEL", "")
+			t.Setenv("K8S_ANALYZER_CONFIG", "")
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			// Without a file, config.yaml is looked up in the working directory
+			t.Chdir(t.TempDir())
+			args := tt.args
+			if tt.file != "" {
+				args = append([]string{"-config", writeConfig(t, tt.file)}, args...)
+			}
+
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			settings := newSettingsFlags(fs).ai()
+			if err := fs.Parse(args); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := settings.load()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if cfg.AI.Model != tt.wantModel {
+				t.Errorf("ai.model = %q, want %q", cfg.AI.Model, tt.wantModel)
+			}
+			if cfg.Thresholds.NodeCPUThreshold != tt.wantCPU {
+				t.Errorf("thresholds.node_cpu_threshold = %v, want %v", cfg.Thresholds.NodeCPUThreshold, tt.wantCPU)
+			}
+			// Settings the file does not mention keep their defaults
+			if cfg.Thresholds.NodeMemoryThreshold != defaults.Thresholds.NodeMemoryThreshold {
+				t.Errorf("thresholds.node_memory_threshold = %v, want the default %v", cfg.Thresholds.NodeMemoryThreshold, defaults.Thresholds.NodeMemoryThreshold)
+			}
+		})
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		wantErr string
+	}{
+		{
+			name:    "unknown key",
+			file:    "thresholds:\n  node_cpu_treshold: 70\n",
+			wantErr: "unknown field",
+		},
+		{
+			name:    "wrong type",
+			file:    "ai:\n  max_tokens: many\n",
+			wantErr: "error parsing config file",
+		},
+		{
+			name:    "invalid value",
+			file:    "ai:\n  temperature: 3\n",
+			wantErr: "ai.temperature must be between 0 and 2",
+		},
+		{
+			name:    "inconsistent risk thresholds",
+			file:    "thresholds:\n  high_risk: 90\n  critical_risk: 80\n",
+			wantErr: "critical_risk >= high_risk >= medium_risk",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadConfig(writeConfig(t, tt.file))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadConfig() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("missing explicit file", func(t *testing.T) {
+		if _, err := LoadConfig(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+			t.Error("LoadConfig() of a missing file succeeded, want an error")
+		}
+	})
+}
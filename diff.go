@@ -0,0 +1,265 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// ReportDiff describes what changed between two exported reports.
+type ReportDiff struct {
+	Old                    ClusterMetadata       `json:"old"`
+	New                    ClusterMetadata       `json:"new"`
+	HealthBefore           HealthScore           `json:"healthBefore"`
+	HealthAfter            HealthScore           `json:"healthAfter"`
+	Metrics                []MetricChange        `json:"metrics"`
+	NewResourceGaps        []ResourceGap         `json:"newResourceGaps"`
+	ResolvedResourceGaps   []ResourceGap         `json:"resolvedResourceGaps"`
+	NewCriticalIssues      []string              `json:"newCriticalIssues"`
+	ResolvedCriticalIssues []string              `json:"resolvedCriticalIssues"`
+	NamespaceRiskChanges   []NamespaceRiskChange `json:"namespaceRiskChanges"`
+}
+
+type MetricChange struct {
+	Name string `json:"name"`
+	Old  int    `json:"old"`
+	New  int    `json:"new"`
+}
+
+type NamespaceRiskChange struct {
+	Namespace string `json:"namespace"`
+	Old       string `json:"old"` // empty when the namespace was not analyzed before
+	New       string `json:"new"` // empty when the namespace is no longer analyzed
+}
+
+func runDiffCommand(cmd *command, args []string) error {
+	fs := cmd.newFlagSet()
+	outputFile := fs.String("output", "", "file to write the comparison to (default: stdout)")
+	format := fs.String("format", "markdown", "comparison format (markdown, json or yaml)")
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("diff needs exactly two report files, got %d", fs.NArg())
+	}
+
+	oldDoc, err := ReadReportDocument(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	newDoc, err := ReadReportDocument(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	diff := DiffReports(oldDoc, newDoc)
+
+	var rendered []byte
+	switch *format {
+	case "markdown":
+		rendered = []byte(generateDiffMarkdown(diff))
+	case "json":
+		rendered, err = json.MarshalIndent(diff, "", "  ")
+		rendered = append(rendered, '\n')
+	case "yaml":
+		rendered, err = yaml.Marshal(diff)
+	default:
+		return fmt.Errorf("diff supports markdown, json and yaml output, got %q", *format)
+	}
+	if err != nil {
+		return fmt.Errorf("error encoding comparison: %w", err)
+	}
+
+	if *outputFile == "" {
+		_, err := os.Stdout.Write(rendered)
+		return err
+	}
+	return os.WriteFile(*outputFile, rendered, 0644)
+}
+
+// DiffReports compares two reports. Resource gaps are matched by namespace,
+// workload and container, and critical issues by title.
+func DiffReports(oldDoc, newDoc *ReportDocument) *ReportDiff {
+	oldA, newA := oldDoc.Analysis, newDoc.Analysis
+
+	diff := &ReportDiff{
+		Old:          oldDoc.Cluster,
+		New:          newDoc.Cluster,
+		HealthBefore: oldA.Health,
+		HealthAfter:  newA.Health,
+		Metrics: []MetricChange{
+			{"Pods", oldDoc.Cluster.Pods, newDoc.Cluster.Pods},
+			{"Nodes", oldDoc.Cluster.Nodes, newDoc.Cluster.Nodes},
+			{"Workload Containers Missing Resources", len(oldA.ResourceGaps), len(newA.ResourceGaps)},
+			{"Critical Issues", len(oldA.CriticalIssues), len(newA.CriticalIssues)},
+			{"OOM Events", len(oldA.OOMEvents), len(newA.OOMEvents)},
+			{"Pods with Restarts (24h)", oldA.PodRestarts.TotalPods24h, newA.PodRestarts.TotalPods24h},
+			{"Pods with Restarts (7d)", oldA.PodRestarts.TotalPods7d, newA.PodRestarts.TotalPods7d},
+			{"Flux Errors (24h)", oldA.FluxEvents.Errors24h, newA.FluxEvents.Errors24h},
+			{"Warning Events (24h)", oldA.NonFluxEvents.Warnings24h, newA.NonFluxEvents.Warnings24h},
+			{"Failed Velero Backups (24h)", oldA.VeleroBackups.FailedBackups24h, newA.VeleroBackups.FailedBackups24h},
+			{"Nodes Not Ready", len(nodesWith(oldA.NodeConditions, nodeNotReady)), len(nodesWith(newA.NodeConditions, nodeNotReady))},
+			{"Node Issues", len(oldA.NodeIssues), len(newA.NodeIssues)},
+			{"High-Risk Namespaces", countHighRiskNamespaces(oldA.NamespaceAnalysis), countHighRiskNamespaces(newA.NamespaceAnalysis)},
+		},
+		NewResourceGaps:        []ResourceGap{},
+		ResolvedResourceGaps:   []ResourceGap{},
+		NewCriticalIssues:      []string{},
+		ResolvedCriticalIssues: []string{},
+		NamespaceRiskChanges:   []NamespaceRiskChange{},
+	}
+
+	gapKey := func(g ResourceGap) string { return workloadKey(g.Namespace, gapWorkload(g), g.Container) }
+	oldGaps := make(map[string]bool)
+	for _, g := range oldA.ResourceGaps {
+		oldGaps[gapKey(g)] = true
+	}
+	newGaps := make(map[string]bool)
+	for _, g := range newA.ResourceGaps {
+		newGaps[gapKey(g)] = true
+		if !oldGaps[gapKey(g)] {
+			diff.NewResourceGaps = append(diff.NewResourceGaps, g)
+		}
+	}
+	for _, g := range oldA.ResourceGaps {
+		if !newGaps[gapKey(g)] {
+			diff.ResolvedResourceGaps = append(diff.ResolvedResourceGaps, g)
+		}
+	}
+
+	oldIssues := make(map[string]bool)
+	for _, issue := range oldA.CriticalIssues {
+		oldIssues[issue.Title] = true
+	}
+	newIssues := make(map[string]bool)
+	for _, issue := range newA.CriticalIssues {
+		newIssues[issue.Title] = true
+		if !oldIssues[issue.Title] {
+			diff.NewCriticalIssues = append(diff.NewCriticalIssues, issue.Title)
+		}
+	}
+	for _, issue := range oldA.CriticalIssues {
+		if !newIssues[issue.Title] {
+			diff.ResolvedCriticalIssues = append(diff.ResolvedCriticalIssues, issue.Title)
+		}
+	}
+
+	risk := make(map[string][2]string)
+	for _, ns := range oldA.NamespaceAnalysis {
+		r := risk[ns.Namespace]
+		r[0] = ns.RiskLevel
+		risk[ns.Namespace] = r
+	}
+	for _, ns := range newA.NamespaceAnalysis {
+		r := risk[ns.Namespace]
+		r[1] = ns.RiskLevel
+		risk[ns.Namespace] = r
+	}
+	for ns, r := range risk {
+		if r[0] != r[1] {
+			diff.NamespaceRiskChanges = append(diff.NamespaceRiskChanges, NamespaceRiskChange{Namespace: ns, Old: r[0], New: r[1]})
+		}
+	}
+	sort.Slice(diff.NamespaceRiskChanges, func(i, j int) bool {
+		return diff.NamespaceRiskChanges[i].Namespace < diff.NamespaceRiskChanges[j].Namespace
+	})
+
+	return diff
+}
+
+func generateDiffMarkdown(diff *ReportDiff) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Cluster Analysis Comparison\n\n")
+	sb.WriteString(fmt.Sprintf("**Before:** `%s` collected %s\n\n", diff.Old.Name, diff.Old.CollectedAt.Format(time.RFC3339)))
+	sb.WriteString(fmt.Sprintf("**After:** `%s` collected %s\n\n", diff.New.Name, diff.New.CollectedAt.Format(time.RFC3339)))
+
+	if diff.HealthBefore.String() == diff.HealthAfter.String() {
+		sb.WriteString(fmt.Sprintf("**Overall Health:** %s (unchanged)\n\n", diff.HealthAfter))
+	} else {
+		sb.WriteString(fmt.Sprintf("**Overall Health:** %s → %s\n\n", diff.HealthBefore, diff.HealthAfter))
+	}
+
+	sb.WriteString("## Key Metrics\n\n")
+	sb.WriteString("| Metric | Before | After | Change |\n")
+	sb.WriteString("|--------|--------|-------|--------|\n")
+	for _, m := range diff.Metrics {
+		change := "-"
+		if delta := m.New - m.Old; delta != 0 {
+			change = fmt.Sprintf("%+d", delta)
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %d | %d | %s |\n", m.Name, m.Old, m.New, change))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("## Critical Issues\n\n")
+	if len(diff.NewCriticalIssues) == 0 && len(diff.ResolvedCriticalIssues) == 0 {
+		sb.WriteString("No change.\n\n")
+	} else {
+		for _, title := range diff.NewCriticalIssues {
+			sb.WriteString(fmt.Sprintf("- 🔴 New: %s\n", title))
+		}
+		for _, title := range diff.ResolvedCriticalIssues {
+			sb.WriteString(fmt.Sprintf("- 🟢 Resolved: %s\n", title))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Namespace Risk Changes\n\n")
+	if len(diff.NamespaceRiskChanges) == 0 {
+		sb.WriteString("No change.\n\n")
+	} else {
+		sb.WriteString("| Namespace | Before | After |\n")
+		sb.WriteString("|-----------|--------|-------|\n")
+		for _, c := range diff.NamespaceRiskChanges {
+			sb.WriteString(fmt.Sprintf("| `%s` | %s | %s |\n", c.Namespace, riskOrDash(c.Old), riskOrDash(c.New)))
+		}
+		sb.WriteString("\n")
+	}
+
+	writeGaps := func(title string, gaps []ResourceGap) {
+		sb.WriteString(fmt.Sprintf("## %s (%d)\n\n", title, len(gaps)))
+		if len(gaps) == 0 {
+			sb.WriteString("None.\n\n")
+			return
+		}
+		sb.WriteString("| Namespace | Workload | Replicas | Container | Missing Requests | Missing Limits |\n")
+		sb.WriteString("|-----------|----------|----------|-----------|------------------|----------------|\n")
+		for _, g := range gaps {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %d | %s | %s | %s |\n", g.Namespace, gapWorkload(g), g.Replicas, containerLabel(g.Container, g.ContainerType), yesNo(g.MissingRequests), yesNo(g.MissingLimits)))
+		}
+		sb.WriteString("\n")
+	}
+	writeGaps("New Resource Gaps", diff.NewResourceGaps)
+	writeGaps("Resolved Resource Gaps", diff.ResolvedResourceGaps)
+
+	return sb.String()
+}
+
+// gapWorkload returns the workload of a gap. Reports written before gaps were
+// grouped by workload only name the pod.
+func gapWorkload(g ResourceGap) Workload {
+	if g.Workload.Name == "" {
+		return Workload{Kind: "Pod", Name: g.PodName}
+	}
+	return g.Workload
+}
+
+func riskOrDash(level string) string {
+	if level == "" {
+		return "-"
+	}
+	return strings.ToUpper(level)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
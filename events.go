@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// collectEvents lists events through events.k8s.io/v1, which carries the
+// event time and series of events emitted by newer components, and falls back
+// to the core API when that group is not served or not allowed.
+func (a *Analyzer) collectEvents(ctx context.Context) ([]corev1.Event, error) {
+	events, err := listScoped(ctx, a, "events.k8s.io", "events", func(ns string) func(context.Context, metav1.ListOptions) ([]corev1.Event, string, error) {
+		return func(ctx context.Context, opts metav1.ListOptions) ([]corev1.Event, string, error) {
+			list, err := a.clientset.EventsV1().Events(ns).List(ctx, opts)
+			if err != nil {
+				return nil, "", err
+			}
+			items := make([]corev1.Event, 0, len(list.Items))
+			for i := range list.Items {
+				items = append(items, coreEvent(&list.Items[i]))
+			}
+			return items, list.Continue, nil
+		}
+	})
+	if err == nil || !(apierrors.IsNotFound(err) || apierrors.IsForbidden(err)) {
+		return events, err
+	}
+
+	return listScoped(ctx, a, "", "events", func(ns string) func(context.Context, metav1.ListOptions) ([]corev1.Event, string, error) {
+		return func(ctx context.Context, opts metav1.ListOptions) ([]corev1.Event, string, error) {
+			list, err := a.clientset.CoreV1().Events(ns).List(ctx, opts)
+			if err != nil {
+				return nil, "", err
+			}
+			for i := range list.Items {
+				list.Items[i].ManagedFields = nil
+			}
+			return list.Items, list.Continue, nil
+		}
+	})
+}
+
+// coreEvent converts an events.k8s.io/v1 event to the core form the analyses
+// and snapshots use. The API server keeps both forms of the same object, so
+// the conversion mirrors its own field mapping.
+func coreEvent(e *eventsv1.Event) corev1.Event {
+	event := corev1.Event{
+		ObjectMeta:          e.ObjectMeta,
+		InvolvedObject:      e.Regarding,
+		Reason:              e.Reason,
+		Message:             e.Note,
+		Source:              e.DeprecatedSource,
+		FirstTimestamp:      e.DeprecatedFirstTimestamp,
+		LastTimestamp:       e.DeprecatedLastTimestamp,
+		Count:               e.DeprecatedCount,
+		Type:                e.Type,
+		EventTime:           e.EventTime,
+		Action:              e.Action,
+		Related:             e.Related,
+		ReportingController: e.ReportingController,
+		ReportingInstance:   e.ReportingInstance,
+	}
+	event.ManagedFields = nil
+	if e.Series != nil {
+		event.Series = &corev1.EventSeries{Count: e.Series.Count, LastObservedTime: e.Series.LastObservedTime}
+	}
+	if event.Source.Component == "" {
+		event.Source.Component = e.ReportingController
+	}
+	return event
+}
+
+// eventLastTime returns when an event was last seen. Newer components leave
+// the legacy timestamps empty and set the event time, plus a series once the
+// event repeats.
+func eventLastTime(e *corev1.Event) time.Time {
+	switch {
+	case e.Series != nil && !e.Series.LastObservedTime.IsZero():
+		return e.Series.LastObservedTime.Time
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	}
+	return e.CreationTimestamp.Time
+}
+
+// eventFirstTime returns when an event was first seen.
+func eventFirstTime(e *corev1.Event) time.Time {
+	switch {
+	case !e.FirstTimestamp.IsZero():
+		return e.FirstTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	}
+	return e.CreationTimestamp.Time
+}
+
+// eventCount returns how many times an event occurred. An event without a
+// series or legacy count occurred once.
+func eventCount(e *corev1.Event) int32 {
+	count := e.Count
+	if e.Series != nil && e.Series.Count > count {
+		count = e.Series.Count
+	}
+	if count < 1 {
+		count = 1
+	}
+	return count
+}
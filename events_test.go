@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+func TestEventTiming(t *testing.T) {
+	created := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	first := created.Add(time.Minute)
+	last := created.Add(time.Hour)
+	observed := created.Add(2 * time.Hour)
+
+	tests := []struct {
+		name      string
+		event     corev1.Event
+		wantFirst time.Time
+		wantLast  time.Time
+		wantCount int32
+	}{
+		{
+			name: "legacy timestamps",
+			event: corev1.Event{
+				FirstTimestamp: metav1.NewTime(first),
+				LastTimestamp:  metav1.NewTime(last),
+				Count:          4,
+			},
+			wantFirst: first,
+			wantLast:  last,
+			wantCount: 4,
+		},
+		{
+			name:      "event time only",
+			event:     corev1.Event{EventTime: metav1.NewMicroTime(first)},
+			wantFirst: first,
+			wantLast:  first,
+			wantCount: 1,
+		},
+		{
+			name: "series",
+			event: corev1.Event{
+				EventTime: metav1.NewMicroTime(first),
+				Series:    &corev1.EventSeries{Count: 7, LastObservedTime: metav1.NewMicroTime(observed)},
+			},
+			wantFirst: first,
+			wantLast:  observed,
+			wantCount: 7,
+		},
+		{
+			name: "series count above the legacy count",
+			event: corev1.Event{
+				FirstTimestamp: metav1.NewTime(first),
+				LastTimestamp:  metav1.NewTime(last),
+				Count:          2,
+				Series:         &corev1.EventSeries{Count: 5, LastObservedTime: metav1.NewMicroTime(observed)},
+			},
+			wantFirst: first,
+			wantLast:  observed,
+			wantCount: 5,
+		},
+		{
+			name:      "no timestamps",
+			event:     corev1.Event{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)}},
+			wantFirst: created,
+			wantLast:  created,
+			wantCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := eventFirstTime(&tt.event); !got.Equal(tt.wantFirst) {
+				t.Errorf("eventFirstTime() = %s, want %s", got, tt.wantFirst)
+			}
+			if got := eventLastTime(&tt.event); !got.Equal(tt.wantLast) {
+				t.Errorf("eventLastTime() = %s, want %s", got, tt.wantLast)
+			}
+			if got := eventCount(&tt.event); got != tt.wantCount {
+				t.Errorf("eventCount() = %d, want %d", got, tt.wantCount)
+			}
+		})
+	}
+}
+
+// eventsAPI serves the event lists and access reviews collectEvents uses.
+// With eventsV1 false the events.k8s.io group answers 404 as if it were not
+// served; with denyEventsV1 the access review for it is denied.
+type eventsAPI struct {
+	eventsV1     bool
+	denyEventsV1 bool
+}
+
+func (f eventsAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	write := func(status int, obj any) {
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(obj)
+	}
+	eventTime := metav1.NewMicroTime(time.Date(2026, 10, 1, 11, 0, 0, 0, time.UTC))
+
+	switch r.URL.Path {
+	case "/apis/authorization.k8s.io/v1/selfsubjectaccessreviews":
+		var review authorizationv1.SelfSubjectAccessReview
+		if err := json.NewDecoder(r.Body).Decode(&review); err != nil || review.Spec.ResourceAttributes == nil {
+			write(http.StatusBadRequest, metav1.Status{Status: metav1.StatusFailure, Reason: metav1.StatusReasonBadRequest, Code: http.StatusBadRequest})
+			return
+		}
+		review.Status.Allowed = !(f.denyEventsV1 && review.Spec.ResourceAttributes.Group == "events.k8s.io")
+		write(http.StatusCreated, review)
+	case "/apis/events.k8s.io/v1/events":
+		if !f.eventsV1 {
+			write(http.StatusNotFound, metav1.Status{Status: metav1.StatusFailure, Reason: metav1.StatusReasonNotFound, Code: http.StatusNotFound})
+			return
+		}
+		write(http.StatusOK, eventsv1.EventList{Items: []eventsv1.Event{{
+			ObjectMeta:          metav1.ObjectMeta{Name: "web.1", Namespace: "app"},
+			Regarding:           corev1.ObjectReference{Kind: "Pod", Namespace: "app", Name: "web"},
+			Reason:              "BackOff",
+			Note:                "Back-off restarting failed container",
+			Type:                corev1.EventTypeWarning,
+			EventTime:           eventTime,
+			ReportingController: "kubelet",
+			Series:              &eventsv1.EventSeries{Count: 3, LastObservedTime: metav1.NewMicroTime(eventTime.Add(time.Hour))},
+		}}})
+	case "/api/v1/events":
+		write(http.StatusOK, corev1.EventList{Items: []corev1.Event{{
+			ObjectMeta:     metav1.ObjectMeta{Name: "web.2", Namespace: "app"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "app", Name: "web"},
+			Reason:         "BackOff",
+			Source:         corev1.EventSource{Component: "kubelet"},
+		}}})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestCollectEvents(t *testing.T) {
+	tests := []struct {
+		name         string
+		api          eventsAPI
+		wantName     string
+		wantMessage  string
+		wantCount    int32
+		wantSeenLast time.Time
+	}{
+		{
+			name:         "events.k8s.io",
+			api:          eventsAPI{eventsV1: true},
+			wantName:     "web.1",
+			wantMessage:  "Back-off restarting failed container",
+			wantCount:    3,
+			wantSeenLast: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "events.k8s.io not served",
+			api:       eventsAPI{},
+			wantName:  "web.2",
+			wantCount: 1,
+		},
+		{
+			name:      "events.k8s.io not allowed",
+			api:       eventsAPI{eventsV1: true, denyEventsV1: true},
+			wantName:  "web.2",
+			wantCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.api)
+			defer server.Close()
+			clientset, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL, ContentConfig: rest.ContentConfig{ContentType: "application/json"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			a := NewAnalyzer(clientset, nil, DefaultConfig())
+			events, err := a.collectEvents(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(events) != 1 {
+				t.Fatalf("got %d events, want 1", len(events))
+			}
+
+			event := events[0]
+			if event.Name != tt.wantName || event.Message != tt.wantMessage {
+				t.Errorf("event = %s %q, want %s %q", event.Name, event.Message, tt.wantName, tt.wantMessage)
+			}
+			if event.Source.Component != "kubelet" || event.InvolvedObject.Name != "web" {
+				t.Errorf("event source %q about %q, want kubelet about web", event.Source.Component, event.InvolvedObject.Name)
+			}
+			if got := eventCount(&event); got != tt.wantCount {
+				t.Errorf("eventCount() = %d, want %d", got, tt.wantCount)
+			}
+			if !tt.wantSeenLast.IsZero() && !eventLastTime(&event).Equal(tt.wantSeenLast) {
+				t.Errorf("eventLastTime() = %s, want %s", eventLastTime(&event), tt.wantSeenLast)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// reportSchemaVersion identifies the layout of the JSON/YAML report. Fields may
+// be added within a version; renames or removals require a new version.
+const reportSchemaVersion = "k8s-resource-analyzer/v2"
+
+// readableSchemaVersions are older report layouts that can still be read for
+// comparison. v1 reported health as the clusterHealth string, which v2
+// replaced with the health score.
+var readableSchemaVersions = map[string]bool{
+	reportSchemaVersion:        true,
+	"k8s-resource-analyzer/v1": true,
+}
+
+// ReportDocument is the machine-readable form of a cluster analysis.
+type ReportDocument struct {
+	SchemaVersion string                                   `json:"schemaVersion"`
+	GeneratedAt   time.Time                                `json:"generatedAt"`
+	Cluster       ClusterMetadata                          `json:"cluster"`
+	Analysis      *Analysis                                `json:"analysis"`
+	AISuggestions map[string]map[string]ResourceSuggestion `json:"aiSuggestions,omitempty"` // namespace -> Kind/name/container -> suggestion
+}
+
+type ClusterMetadata struct {
+	Name             string    `json:"name"`
+	CollectedAt      time.Time `json:"collectedAt"`
+	Pods             int       `json:"pods"`
+	Nodes            int       `json:"nodes"`
+	Events           int       `json:"events"`
+	Namespaces       int       `json:"namespaces"`
+	VeleroBackups    int       `json:"veleroBackups"`
+	MetricsAvailable bool      `json:"metricsAvailable"`
+	Scope            []string  `json:"scope,omitempty"` // namespaces analyzed in namespace-scoped mode
+
+	CollectionErrors []CollectionError `json:"collectionErrors,omitempty"` // sources that could not be collected
+}
+
+// NewReportDocument assembles the exported document from collected data and analysis.
+func NewReportDocument(data *ClusterData, analysis *Analysis) *ReportDocument {
+	return &ReportDocument{
+		SchemaVersion: reportSchemaVersion,
+		GeneratedAt:   time.Now(),
+		Cluster: ClusterMetadata{
+			Name:             data.ClusterName,
+			CollectedAt:      data.CollectedAt,
+			Pods:             len(data.Pods),
+			Nodes:            len(data.Nodes),
+			Events:           len(data.Events),
+			Namespaces:       len(data.Namespaces),
+			VeleroBackups:    len(data.VeleroBackups),
+			MetricsAvailable: len(data.PodMetrics) > 0,
+			Scope:            data.Scope,
+			CollectionErrors: data.CollectionErrors,
+		},
+		Analysis:      analysis,
+		AISuggestions: data.AISuggestions,
+	}
+}
+
+// ReadReportDocument loads a JSON or YAML report previously written with -format=json or -format=yaml.
+func ReadReportDocument(path string) (*ReportDocument, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading report %s: %w", path, err)
+	}
+
+	// YAML is a superset of JSON, so one decoder handles both formats
+	var doc ReportDocument
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("error parsing report %s: %w", path, err)
+	}
+
+	if !readableSchemaVersions[doc.SchemaVersion] {
+		return nil, fmt.Errorf("report %s has schema %q, expected %q", path, doc.SchemaVersion, reportSchemaVersion)
+	}
+	if doc.Analysis == nil {
+		return nil, fmt.Errorf("report %s contains no analysis", path)
+	}
+
+	return &doc, nil
+}
+
+// RenderReport produces the report in the configured output format.
+func RenderReport(data *ClusterData, analysis *Analysis, cfg *Config) ([]byte, error) {
+	switch cfg.Output.Format {
+	case "markdown":
+		return []byte(GenerateReport(data, analysis, cfg)), nil
+	case "json":
+		out, err := json.MarshalIndent(NewReportDocument(data, analysis), "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("error encoding JSON report: %w", err)
+		}
+		return append(out, '\n'), nil
+	case "yaml":
+		out, err := yaml.Marshal(NewReportDocument(data, analysis))
+		if err != nil {
+			return nil, fmt.Errorf("error encoding YAML report: %w", err)
+		}
+		return out, nil
+	case "html":
+		out, err := GenerateHTMLReport(data, analysis, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(out), nil
+	default:
+		return nil, fmt.Errorf("unsupported output format %q", cfg.Output.Format)
+	}
+}
+
+// reportFileExtension returns the file extension used for auto-generated report names.
+func reportFileExtension(format string) string {
+	switch format {
+	case "json":
+		return "json"
+	case "yaml":
+		return "yaml"
+	case "html":
+		return "html"
+	default:
+		return "md"
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+func TestRenderReportRoundTrip(t *testing.T) {
+	collectedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	data := &ClusterData{ClusterName: "prod", CollectedAt: collectedAt}
+	analysis := &Analysis{
+		Health: HealthScore{Score: 75, Status: healthDegraded},
+		CriticalIssues: []CriticalIssue{{
+			Priority: 1,
+			Title:    "OOMKilled Events Detected",
+			Examples: []string{"app/web"},
+		}},
+		ResourceGaps: []ResourceGap{{Namespace: "app", PodName: "web", Container: "main", MissingRequests: true}},
+		OOMEvents:    []OOMEvent{{NodeName: "n1", PodName: "web", Namespace: "app", Container: "main", Timestamp: collectedAt}},
+	}
+
+	unmarshal := map[string]func([]byte, any) error{
+		"json": json.Unmarshal,
+		"yaml": func(b []byte, v any) error { return yaml.Unmarshal(b, v) },
+	}
+
+	for format, decode := range unmarshal {
+		t.Run(format, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Output.Format = format
+
+			out, err := RenderReport(data, analysis, cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var doc ReportDocument
+			if err := decode(out, &doc); err != nil {
+				t.Fatalf("decoding %s report: %v", format, err)
+			}
+
+			if doc.SchemaVersion != reportSchemaVersion {
+				t.Errorf("schemaVersion = %q, want %q", doc.SchemaVersion, reportSchemaVersion)
+			}
+			if doc.Cluster.Name != "prod" || !doc.Cluster.CollectedAt.Equal(collectedAt) {
+				t.Errorf("cluster = %+v, want name prod collected at %s", doc.Cluster, collectedAt)
+			}
+			if doc.Analysis == nil {
+				t.Fatal("analysis missing from the decoded report")
+			}
+			if doc.Analysis.Health.Score != analysis.Health.Score || doc.Analysis.Health.Status != analysis.Health.Status {
+				t.Errorf("health = %+v, want %+v", doc.Analysis.Health, analysis.Health)
+			}
+			if len(doc.Analysis.CriticalIssues) != 1 || doc.Analysis.CriticalIssues[0].Title != analysis.CriticalIssues[0].Title {
+				t.Errorf("criticalIssues = %+v, want %+v", doc.Analysis.CriticalIssues, analysis.CriticalIssues)
+			}
+			if len(doc.Analysis.ResourceGaps) != 1 || doc.Analysis.ResourceGaps[0] != analysis.ResourceGaps[0] {
+				t.Errorf("resourceGaps = %+v, want %+v", doc.Analysis.ResourceGaps, analysis.ResourceGaps)
+			}
+			if len(doc.Analysis.OOMEvents) != 1 || !doc.Analysis.OOMEvents[0].Timestamp.Equal(collectedAt) {
+				t.Errorf("oomEvents = %+v, want one event at %s", doc.Analysis.OOMEvents, collectedAt)
+			}
+		})
+	}
+}
+
+func TestReadReportDocument(t *testing.T) {
+	data := &ClusterData{ClusterName: "prod"}
+	analysis := &Analysis{Health: HealthScore{Score: 75, Status: healthDegraded}}
+
+	write := func(t *testing.T, name string, content []byte) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), name)
+		if err := os.WriteFile(path, content, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	for _, format := range []string{"json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Output.Format = format
+			out, err := RenderReport(data, analysis, cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			doc, err := ReadReportDocument(write(t, "report."+format, out))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if doc.SchemaVersion != reportSchemaVersion || doc.Cluster.Name != "prod" || doc.Analysis.Health.Score != 75 {
+				t.Errorf("read %s %s with health %+v", doc.SchemaVersion, doc.Cluster.Name, doc.Analysis.Health)
+			}
+		})
+	}
+
+	t.Run("v1 report", func(t *testing.T) {
+		v1 := `{"schemaVersion": "k8s-resource-analyzer/v1", "cluster": {"name": "prod"},
+			"analysis": {"clusterHealth": "DEGRADED", "resourceGaps": [{"namespace": "app", "podName": "web", "container": "main", "missingRequests": true}]}}`
+		doc, err := ReadReportDocument(write(t, "report.json", []byte(v1)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(doc.Analysis.ResourceGaps) != 1 {
+			t.Errorf("resourceGaps = %+v, want the gap of the v1 report", doc.Analysis.ResourceGaps)
+		}
+		if doc.Analysis.Health.Status != "" {
+			t.Errorf("health status = %q, want none for a v1 report", doc.Analysis.Health.Status)
+		}
+	})
+
+	errors := map[string]string{
+		"unknown schema":   `{"schemaVersion": "other/v1", "analysis": {}}`,
+		"missing analysis": `{"schemaVersion": "k8s-resource-analyzer/v2"}`,
+		"not a report":     `[1, 2`,
+	}
+	for name, content := range errors {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ReadReportDocument(write(t, "report.json", []byte(content))); err == nil {
+				t.Error("ReadReportDocument() succeeded, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,579 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Finding severities, most severe first.
+const (
+	severityCritical = "critical"
+	severityHigh     = "high"
+	severityMedium   = "medium"
+	severityLow      = "low"
+	severityInfo     = "info"
+)
+
+// severities lists the severities in order, most severe first.
+var severities = []string{severityCritical, severityHigh, severityMedium, severityLow, severityInfo}
+
+// Finding categories.
+const (
+	categoryResources  = "resources"
+	categoryNodes      = "nodes"
+	categoryStability  = "stability"
+	categoryEvents     = "events"
+	categoryBackups    = "backups"
+	categoryNamespaces = "namespaces"
+)
+
+// Rule IDs. They appear in reports, waivers and baselines, so they must not
+// change once released.
+const (
+	ruleMissingRequests        = "resources/missing-requests"
+	ruleMissingLimits          = "resources/missing-limits"
+	ruleNodeNotReady           = "nodes/not-ready"
+	ruleNodeNetworkUnavailable = "nodes/network-unavailable"
+	ruleNodeMemoryPressure     = "nodes/memory-pressure"
+	ruleNodeDiskPressure       = "nodes/disk-pressure"
+	ruleNodePIDPressure        = "nodes/pid-pressure"
+	ruleNodeCordoned           = "nodes/cordoned"
+	ruleNodeHighCPURequests    = "nodes/high-cpu-requests"
+	ruleNodeHighMemoryRequests = "nodes/high-memory-requests"
+	ruleNodePoolOutlier        = "nodes/pool-outlier"
+	ruleNodeHot                = "nodes/hot"
+	ruleNodeOverReserved       = "nodes/over-reserved"
+	ruleNodeOOMRisk            = "nodes/oom-risk"
+	ruleOOMKilled              = "stability/oom-killed"
+	ruleRestarts               = "stability/restarts"
+	ruleRabbitMQPriority       = "stability/rabbitmq-priority-class"
+	ruleRabbitMQLimits         = "stability/rabbitmq-memory-limits"
+	ruleShortLivedJobs         = "stability/short-lived-jobs"
+	ruleNamespaceRisk          = "namespaces/high-risk"
+	ruleFluxWarning            = "events/flux-warning"
+	ruleWarningEvent           = "events/warning"
+	ruleBackupFailed           = "backups/failed"
+)
+
+// Rule describes a kind of finding and its default severity.
+type Rule struct {
+	ID          string `json:"id"`
+	Category    string `json:"category"`
+	Severity    string `json:"severity"`
+	Title       string `json:"title"`
+	Remediation string `json:"remediation"`
+}
+
+// rules holds every rule by ID.
+var rules = map[string]Rule{}
+
+func init() {
+	for _, r := range []Rule{
+		{ruleMissingRequests, categoryResources, severityHigh, "Container without resource requests",
+			"Set CPU and memory requests from observed usage so the scheduler can place the pod and it is not evicted first"},
+		{ruleMissingLimits, categoryResources, severityMedium, "Container without resource limits",
+			"Set a memory limit, and a CPU limit where throttling is acceptable, so one container cannot starve its node"},
+		{ruleNodeNotReady, categoryNodes, severityCritical, "Node not ready",
+			"Check the kubelet and container runtime on the node (kubectl describe node) and replace it if it does not recover"},
+		{ruleNodeNetworkUnavailable, categoryNodes, severityCritical, "Node network unavailable",
+			"Check the CNI plugin and routes on the node"},
+		{ruleNodeMemoryPressure, categoryNodes, severityHigh, "Node under memory pressure",
+			"Lower memory use on the node or move workloads; the kubelet is evicting pods"},
+		{ruleNodeDiskPressure, categoryNodes, severityHigh, "Node under disk pressure",
+			"Free disk space or grow the node's disk; clean up unused images and logs"},
+		{ruleNodePIDPressure, categoryNodes, severityHigh, "Node under PID pressure",
+			"Find workloads leaking processes and set pod PID limits"},
+		{ruleNodeCordoned, categoryNodes, severityLow, "Node cordoned",
+			"Finish the drain and remove the node, or uncordon it"},
+		{ruleNodeHighCPURequests, categoryNodes, severityMedium, "Node with high CPU requests",
+			"Scale the node pool or rebalance workloads across nodes"},
+		{ruleNodeHighMemoryRequests, categoryNodes, severityMedium, "Node with high memory requests",
+			"Scale the node pool or rebalance workloads across nodes"},
+		{ruleNodePoolOutlier, categoryNodes, severityLow, "Node unbalanced within its pool",
+			"Spread replicas with topology spread constraints or pod anti-affinity, and let the descheduler rebalance long-running pods"},
+		{ruleNodeHot, categoryNodes, severityHigh, "Node with high actual usage",
+			"Add capacity or move busy workloads off the node"},
+		{ruleNodeOverReserved, categoryNodes, severityLow, "Node full on requests but not in use",
+			"Lower the requests of the pods on the node to match their usage before adding capacity"},
+		{ruleNodeOOMRisk, categoryNodes, severityMedium, "Node memory limits exceed allocatable memory",
+			"Set memory limits on every container and keep the sum of limits close to allocatable memory"},
+		{ruleOOMKilled, categoryStability, severityHigh, "Container OOM killed",
+			"Raise the container's memory limit or reduce its memory use"},
+		{ruleRestarts, categoryStability, severityMedium, "Container restarting",
+			"Check the container's logs and last termination reason, and its probes and resources"},
+		{ruleRabbitMQPriority, categoryStability, severityMedium, "RabbitMQ without a high priority class",
+			"Give RabbitMQ a PriorityClass above regular workloads so it is evicted last"},
+		{ruleRabbitMQLimits, categoryStability, severityMedium, "RabbitMQ without memory limits",
+			"Set memory requests and limits on RabbitMQ and align vm_memory_high_watermark with them"},
+		{ruleShortLivedJobs, categoryStability, severityInfo, "Short-lived jobs",
+			"Batch short jobs or run them as a long-lived worker to reduce scheduling churn"},
+		{ruleNamespaceRisk, categoryNamespaces, severityMedium, "Namespace with many pods without requests",
+			"Add a LimitRange with default requests to the namespace and fix the listed workloads"},
+		{ruleFluxWarning, categoryEvents, severityMedium, "Flux warning",
+			"Check the Flux resource with flux get and fix the reconciliation error"},
+		{ruleWarningEvent, categoryEvents, severityLow, "Warning event",
+			"Inspect the object the event is about with kubectl describe"},
+		{ruleBackupFailed, categoryBackups, severityHigh, "Velero backup failed",
+			"Check the backup with velero backup describe --details and its logs"},
+	} {
+		rules[r.ID] = r
+	}
+}
+
+// Finding is one problem found by an analysis, on one object.
+type Finding struct {
+	ID          string            `json:"id"` // stable across runs: rule, object and qualifier
+	RuleID      string            `json:"ruleId"`
+	Severity    string            `json:"severity"`
+	Category    string            `json:"category"`
+	Title       string            `json:"title"`
+	Object      ObjectRef         `json:"object"`
+	Container   string            `json:"container,omitempty"`
+	Message     string            `json:"message"`
+	Evidence    map[string]string `json:"evidence,omitempty"`
+	Remediation string            `json:"remediation"`
+	Check       string            `json:"check,omitempty"`  // ID of the check that found it
+	Status      string            `json:"status,omitempty"` // "new", "persisting" or "resolved" against a baseline
+}
+
+// ObjectRef identifies the object a finding is about. It is empty for
+// findings about the cluster as a whole.
+type ObjectRef struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// String returns "Kind/namespace/name", "Kind/name" for cluster-scoped
+// objects, or "cluster".
+func (o ObjectRef) String() string {
+	if o.Kind == "" {
+		return "cluster"
+	}
+	if o.Namespace == "" {
+		return o.Kind + "/" + o.Name
+	}
+	return o.Kind + "/" + o.Namespace + "/" + o.Name
+}
+
+// kindAPIVersions are the API versions of the kinds findings refer to.
+var kindAPIVersions = map[string]string{
+	"Pod":         "v1",
+	"Node":        "v1",
+	"Namespace":   "v1",
+	"Deployment":  "apps/v1",
+	"StatefulSet": "apps/v1",
+	"DaemonSet":   "apps/v1",
+	"ReplicaSet":  "apps/v1",
+	"Job":         "batch/v1",
+	"CronJob":     "batch/v1",
+	"Backup":      "velero.io/v1",
+}
+
+// objectRef refers to an object by kind, filling in the API version of
+// well-known kinds.
+func objectRef(kind, namespace, name string) ObjectRef {
+	return ObjectRef{APIVersion: kindAPIVersions[kind], Kind: kind, Namespace: namespace, Name: name}
+}
+
+// newFinding creates a finding of a rule with the rule's category, severity,
+// title and remediation. The qualifier, e.g. a container name, tells apart
+// findings of one rule on the same object.
+func newFinding(ruleID string, object ObjectRef, qualifier, message string, evidence map[string]string) Finding {
+	rule := rules[ruleID]
+	id := ruleID + ":" + object.String()
+	if qualifier != "" {
+		id += "/" + qualifier
+	}
+	return Finding{
+		ID:          id,
+		RuleID:      ruleID,
+		Severity:    rule.Severity,
+		Category:    rule.Category,
+		Title:       rule.Title,
+		Object:      object,
+		Message:     message,
+		Evidence:    evidence,
+		Remediation: rule.Remediation,
+	}
+}
+
+// sortFindings orders findings by severity, then rule and ID.
+func sortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity != findings[j].Severity {
+			return severityOrder(findings[i].Severity) < severityOrder(findings[j].Severity)
+		}
+		if findings[i].RuleID != findings[j].RuleID {
+			return findings[i].RuleID < findings[j].RuleID
+		}
+		return findings[i].ID < findings[j].ID
+	})
+}
+
+func severityOrder(severity string) int {
+	for i, s := range severities {
+		if s == severity {
+			return i
+		}
+	}
+	return len(severities)
+}
+
+// RuleCount is the number of findings of a rule.
+type RuleCount struct {
+	Rule     Rule
+	Findings int
+}
+
+// countByRule counts findings per rule, ordered like the findings: most
+// severe first.
+func countByRule(findings []Finding) []RuleCount {
+	var counts []RuleCount
+	index := make(map[string]int)
+	for _, f := range findings {
+		i, ok := index[f.RuleID]
+		if !ok {
+			i = len(counts)
+			index[f.RuleID] = i
+			counts = append(counts, RuleCount{Rule: rules[f.RuleID]})
+		}
+		counts[i].Findings++
+	}
+	return counts
+}
+
+// countBySeverity counts findings per severity.
+func countBySeverity(findings []Finding) map[string]int {
+	counts := make(map[string]int)
+	for _, f := range findings {
+		counts[f.Severity]++
+	}
+	return counts
+}
+
+// severitySummary describes finding counts, e.g. "2 critical, 5 high, 1 low".
+func severitySummary(findings []Finding) string {
+	return severityCountSummary(countBySeverity(findings))
+}
+
+// severityCountSummary describes counts per severity, most severe first.
+func severityCountSummary(counts map[string]int) string {
+	var parts []string
+	for _, s := range severities {
+		if counts[s] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[s], s))
+		}
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func resourceGapFindings(gaps []ResourceGap) []Finding {
+	var findings []Finding
+	for _, gap := range gaps {
+		object := objectRef(gap.Workload.Kind, gap.Namespace, gap.Workload.Name)
+		// Each finding gets its own evidence map
+		evidence := func() map[string]string {
+			return map[string]string{
+				"containerType": gap.ContainerType,
+				"replicas":      fmt.Sprintf("%d", gap.Replicas),
+				"pod":           gap.PodName,
+			}
+		}
+		if gap.MissingRequests {
+			f := newFinding(ruleMissingRequests, object, gap.Container,
+				fmt.Sprintf("Container %s of %s has no resource requests", containerLabel(gap.Container, gap.ContainerType), gap.Workload), evidence())
+			f.Container = gap.Container
+			findings = append(findings, f)
+		}
+		if gap.MissingLimits {
+			f := newFinding(ruleMissingLimits, object, gap.Container,
+				fmt.Sprintf("Container %s of %s has no resource limits", containerLabel(gap.Container, gap.ContainerType), gap.Workload), evidence())
+			f.Container = gap.Container
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// nodeConditionRules maps node problems to their rules.
+var nodeConditionRules = map[string]string{
+	nodeNotReady:           ruleNodeNotReady,
+	nodeNetworkUnavailable: ruleNodeNetworkUnavailable,
+	nodeMemoryPressure:     ruleNodeMemoryPressure,
+	nodeDiskPressure:       ruleNodeDiskPressure,
+	nodePIDPressure:        ruleNodePIDPressure,
+	nodeCordoned:           ruleNodeCordoned,
+}
+
+func nodeConditionFindings(issues []NodeConditionIssue) []Finding {
+	var findings []Finding
+	for _, issue := range issues {
+		evidence := map[string]string{"reason": issue.Reason}
+		if issue.Since != nil {
+			evidence["since"] = issue.Since.Format("2006-01-02T15:04:05Z07:00")
+		}
+		if issue.Taint != "" {
+			evidence["taint"] = issue.Taint
+		}
+		findings = append(findings, newFinding(nodeConditionRules[issue.Problem], objectRef("Node", "", issue.NodeName), "",
+			fmt.Sprintf("Node %s is %s: %s", issue.NodeName, issue.Problem, issue.Message), evidence))
+	}
+	return findings
+}
+
+func nodeIssueFindings(issues []NodeIssue) []Finding {
+	var findings []Finding
+	for _, issue := range issues {
+		ruleID, percent := ruleNodeHighCPURequests, issue.CPUPercent
+		if issue.Issue == "High memory requests" {
+			ruleID, percent = ruleNodeHighMemoryRequests, issue.MemoryPercent
+		}
+		findings = append(findings, newFinding(ruleID, objectRef("Node", "", issue.NodeName), "",
+			fmt.Sprintf("Node %s has %.1f%% of allocatable %s requested", issue.NodeName, percent, strings.TrimSuffix(strings.TrimPrefix(issue.Issue, "High "), " requests")),
+			map[string]string{
+				"cpuPercent":    fmt.Sprintf("%.1f", issue.CPUPercent),
+				"memoryPercent": fmt.Sprintf("%.1f", issue.MemoryPercent),
+				"pods":          fmt.Sprintf("%d", issue.PodCount),
+			}))
+	}
+	return findings
+}
+
+func nodePoolFindings(pools []NodePoolAnalysis) []Finding {
+	var findings []Finding
+	for _, pool := range pools {
+		for _, outlier := range pool.Outliers {
+			findings = append(findings, newFinding(ruleNodePoolOutlier, objectRef("Node", "", outlier.NodeName), outlier.Resource,
+				fmt.Sprintf("Node %s in pool %s: %s", outlier.NodeName, pool.Pool, outlier),
+				map[string]string{
+					"pool":     pool.Pool,
+					"resource": outlier.Resource,
+					"percent":  fmt.Sprintf("%.1f", outlier.Percent),
+					"poolMean": fmt.Sprintf("%.1f", outlier.PoolMean),
+				}))
+		}
+	}
+	return findings
+}
+
+func nodeUtilizationFindings(utilization []NodeUtilization) []Finding {
+	var findings []Finding
+	for _, u := range utilization {
+		ruleID := ""
+		switch u.Assessment {
+		case nodeHot:
+			ruleID = ruleNodeHot
+		case nodeOverReserved:
+			ruleID = ruleNodeOverReserved
+		default:
+			continue
+		}
+		findings = append(findings, newFinding(ruleID, objectRef("Node", "", u.NodeName), "",
+			fmt.Sprintf("Node %s uses %.1f%% CPU and %.1f%% memory of allocatable, %.1f%% and %.1f%% of its requests",
+				u.NodeName, u.CPUUsagePercent, u.MemoryUsagePercent, u.CPUUsageOfRequests, u.MemoryUsageOfRequests),
+			map[string]string{
+				"pool":               u.Pool,
+				"cpuUsagePercent":    fmt.Sprintf("%.1f", u.CPUUsagePercent),
+				"memoryUsagePercent": fmt.Sprintf("%.1f", u.MemoryUsagePercent),
+			}))
+	}
+	return findings
+}
+
+func nodeOOMRiskFindings(risks []NodeOOMRisk) []Finding {
+	var findings []Finding
+	for _, r := range risks {
+		if r.Risk == oomRiskLow {
+			continue
+		}
+		f := newFinding(ruleNodeOOMRisk, objectRef("Node", "", r.NodeName), "",
+			fmt.Sprintf("Node %s has memory limits of %.2fx allocatable and %d containers without a memory limit, with %.1f%% memory in use",
+				r.NodeName, r.MemoryOvercommit, r.UnboundedContainers, r.MemoryPercent),
+			map[string]string{
+				"memoryOvercommit":    fmt.Sprintf("%.2f", r.MemoryOvercommit),
+				"unboundedContainers": fmt.Sprintf("%d", r.UnboundedContainers),
+				"memoryPercent":       fmt.Sprintf("%.1f", r.MemoryPercent),
+				"oomKills":            fmt.Sprintf("%d", r.OOMKills),
+			})
+		if r.Risk == oomRiskHigh {
+			f.Severity = severityHigh
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// oomFindings reports each OOM-killed container of a workload once, with the
+// number of kills found across its pods.
+func oomFindings(events []OOMEvent) []Finding {
+	var findings []Finding
+	kills := make(map[string]int) // finding ID -> kills
+	for _, event := range events {
+		f := newFinding(ruleOOMKilled, objectRef(event.Workload.Kind, event.Namespace, event.Workload.Name), event.Container,
+			fmt.Sprintf("Container %s of %s in namespace %s was OOM killed", event.Container, event.Workload, event.Namespace),
+			map[string]string{
+				"lastKill": event.Timestamp.Format("2006-01-02T15:04:05Z07:00"),
+				"pod":      event.PodName,
+				"node":     event.NodeName,
+			})
+		f.Container = event.Container
+		if event.MemoryLimit != "" {
+			f.Evidence["memoryLimit"] = event.MemoryLimit
+		}
+		if event.MemoryUsage != "" {
+			f.Evidence["memoryUsage"] = event.MemoryUsage
+		}
+
+		// Events are sorted most recent first, so the first one seen is the latest
+		kills[f.ID]++
+		if kills[f.ID] == 1 {
+			findings = append(findings, f)
+		}
+	}
+	for i := range findings {
+		findings[i].Evidence["kills"] = fmt.Sprintf("%d", kills[findings[i].ID])
+	}
+	return findings
+}
+
+// restartFindings reports containers that restarted in the last 7 days; those
+// that also restarted in the last 24 hours are more severe.
+func restartFindings(restarts PodRestartAnalysis) []Finding {
+	recent := make(map[string]bool)
+	for _, r := range restarts.Last24Hours {
+		recent[workloadKey(r.Namespace, r.Workload, r.ContainerName)] = true
+	}
+
+	var findings []Finding
+	for _, r := range restarts.Last7Days {
+		f := newFinding(ruleRestarts, objectRef(r.Workload.Kind, r.Namespace, r.Workload.Name), r.ContainerName,
+			fmt.Sprintf("Container %s of %s restarted %d times in 7 days (%s)", r.ContainerName, r.Workload, r.RestartCount, r.Reason),
+			map[string]string{
+				"restarts":    fmt.Sprintf("%d", r.RestartCount),
+				"pods":        fmt.Sprintf("%d", r.Pods),
+				"lastRestart": r.LastRestartTime.Format("2006-01-02T15:04:05Z07:00"),
+				"reason":      r.Reason,
+			})
+		f.Container = r.ContainerName
+		if !recent[workloadKey(r.Namespace, r.Workload, r.ContainerName)] {
+			f.Severity = severityLow
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+func namespaceFindings(namespaces []NamespaceAnalysis) []Finding {
+	var findings []Finding
+	for _, ns := range namespaces {
+		if ns.RiskLevel != "critical" && ns.RiskLevel != "high" {
+			continue
+		}
+		f := newFinding(ruleNamespaceRisk, objectRef("Namespace", "", ns.Namespace), "",
+			fmt.Sprintf("%d of %d pods in namespace %s have no resource requests", ns.PodsWithoutRequests, ns.TotalPods, ns.Namespace),
+			map[string]string{
+				"riskLevel":           ns.RiskLevel,
+				"podsWithoutRequests": fmt.Sprintf("%d", ns.PodsWithoutRequests),
+				"podsWithoutLimits":   fmt.Sprintf("%d", ns.PodsWithoutLimits),
+				"totalPods":           fmt.Sprintf("%d", ns.TotalPods),
+			})
+		if ns.RiskLevel == "critical" {
+			f.Severity = severityHigh
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// rabbitMQFindings reports RabbitMQ workloads without a priority class above
+// regular workloads or without a memory limit.
+func rabbitMQFindings(rabbit RabbitMQAnalysis) []Finding {
+	var findings []Finding
+	for _, w := range rabbit.Workloads {
+		object := objectRef(w.Workload.Kind, w.Namespace, w.Workload.Name)
+		if !w.HasPriorityClass {
+			findings = append(findings, newFinding(ruleRabbitMQPriority, object, "",
+				fmt.Sprintf("RabbitMQ %s in namespace %s has no priority class above regular workloads", w.Workload, w.Namespace),
+				map[string]string{"pods": fmt.Sprintf("%d", w.Pods)}))
+		}
+		if !w.HasMemoryLimit {
+			findings = append(findings, newFinding(ruleRabbitMQLimits, object, "",
+				fmt.Sprintf("RabbitMQ %s in namespace %s has no memory limit", w.Workload, w.Namespace),
+				map[string]string{"pods": fmt.Sprintf("%d", w.Pods)}))
+		}
+	}
+	return findings
+}
+
+// jobFindings reports the Jobs and CronJobs whose pods finish within the
+// short job duration.
+func jobFindings(jobs JobAnalysis, thresholdMinutes float64) []Finding {
+	var findings []Finding
+	for _, w := range jobs.Workloads {
+		findings = append(findings, newFinding(ruleShortLivedJobs, objectRef(w.Workload.Kind, w.Namespace, w.Workload.Name), "",
+			fmt.Sprintf("%d of %d job pods of %s in namespace %s completed in under %g minutes", w.ShortJobs, w.TotalJobs, w.Workload, w.Namespace, thresholdMinutes),
+			map[string]string{
+				"shortJobs": fmt.Sprintf("%d", w.ShortJobs),
+				"totalJobs": fmt.Sprintf("%d", w.TotalJobs),
+			}))
+	}
+	return findings
+}
+
+// eventFindings reports the warning and error events of the last 24 hours
+// under a rule, once per workload, or other object, and reason. Errors are one
+// severity level above warnings.
+func eventFindings(ruleID string, events []EventInfo, workloads *workloadResolver) []Finding {
+	var findings []Finding
+	seen := make(map[string]bool)
+	for _, event := range events {
+		if event.Type != "Warning" && event.Type != "Error" {
+			continue
+		}
+		kind, name, _ := strings.Cut(event.InvolvedObject, "/")
+		workload := workloads.resolveObject(kind, event.Namespace, name)
+		f := newFinding(ruleID, objectRef(workload.Kind, event.Namespace, workload.Name), event.Reason,
+			fmt.Sprintf("%s %s: %s", event.InvolvedObject, event.Reason, event.Message),
+			map[string]string{
+				"object":   event.InvolvedObject,
+				"type":     event.Type,
+				"reason":   event.Reason,
+				"count":    fmt.Sprintf("%d", event.Count),
+				"lastSeen": event.LastTime.Format("2006-01-02T15:04:05Z07:00"),
+			})
+		if event.Type == "Error" {
+			f.Severity = severities[max(severityOrder(f.Severity)-1, 0)]
+		}
+
+		// Events are sorted most recent first, so the first one seen is the latest
+		if seen[f.ID] {
+			continue
+		}
+		seen[f.ID] = true
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+func veleroFindings(backups VeleroBackupAnalysis) []Finding {
+	var findings []Finding
+	for _, backup := range backups.Last24Hours {
+		if backup.Status != "Failed" && backup.Status != "PartiallyFailed" {
+			continue
+		}
+		findings = append(findings, newFinding(ruleBackupFailed, objectRef("Backup", backup.Namespace, backup.Name), "",
+			fmt.Sprintf("Velero backup %s finished as %s", backup.Name, backup.Status),
+			map[string]string{
+				"status":   backup.Status,
+				"errors":   fmt.Sprintf("%d", backup.Errors),
+				"warnings": fmt.Sprintf("%d", backup.Warnings),
+			}))
+	}
+	return findings
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+)
+
+// fleetSchemaVersion identifies the layout of the JSON/YAML fleet summary.
+const fleetSchemaVersion = "k8s-resource-analyzer/fleet/v1"
+
+// FleetResult is the outcome of analyzing a single kubeconfig context.
+type FleetResult struct {
+	Context            string    `json:"context"`
+	ClusterName        string    `json:"clusterName,omitempty"`
+	Health             string    `json:"health,omitempty"`
+	HealthScore        int       `json:"healthScore"` // 0-100
+	Nodes              int       `json:"nodes"`
+	Pods               int       `json:"pods"`
+	ResourceGaps       int       `json:"resourceGaps"`
+	OOMEvents          int       `json:"oomEvents"`
+	PodsRestarted24h   int       `json:"podsRestarted24h"`
+	PodsRestarted7d    int       `json:"podsRestarted7d"`
+	VeleroFailed24h    int       `json:"veleroFailed24h"`
+	NodeIssues         int       `json:"nodeIssues"`
+	HighRiskNamespaces int       `json:"highRiskNamespaces"`
+	DataGaps           []string  `json:"dataGaps,omitempty"` // sources that could not be collected
+	CollectedAt        time.Time `json:"collectedAt,omitempty"`
+	ReportFile         string    `json:"reportFile,omitempty"`
+	Error              string    `json:"error,omitempty"`
+
+	Err error `json:"-"`
+}
+
+// FleetSummary is the machine-readable form of a multi-cluster run.
+type FleetSummary struct {
+	SchemaVersion string        `json:"schemaVersion"`
+	GeneratedAt   time.Time     `json:"generatedAt"`
+	Clusters      []FleetResult `json:"clusters"`
+}
+
+// listContexts returns every context name in the kubeconfig, sorted.
+func listContexts(kubeconfig string) ([]string, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = kubeconfig
+
+	raw, err := rules.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(raw.Contexts))
+	for name := range raw.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// splitList parses a comma-separated flag value, dropping blanks and duplicates.
+func splitList(value string) []string {
+	seen := make(map[string]bool)
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" || seen[item] {
+			continue
+		}
+		seen[item] = true
+		items = append(items, item)
+	}
+	return items
+}
+
+// RunFleet analyzes each context concurrently, writing a report per cluster to
+// outputDir. A failing cluster is recorded in its result and does not stop the others.
+func RunFleet(ctx context.Context, cfg *Config, contexts []string, outputDir string, parallel int) []FleetResult {
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	// One AI client is shared; it is safe for concurrent use
+	aiClient := newAIClientFromEnv(cfg, progress{})
+
+	results := make([]FleetResult, len(contexts))
+	sem := make(chan struct{}, parallel)
+	var wg sync.WaitGroup
+
+	for i, kubeContext := range contexts {
+		wg.Add(1)
+		go func(i int, kubeContext string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = analyzeContext(ctx, cfg, kubeContext, outputDir, aiClient)
+		}(i, kubeContext)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func analyzeContext(ctx context.Context, cfg *Config, kubeContext, outputDir string, aiClient *AIClient) FleetResult {
+	result := FleetResult{Context: kubeContext}
+	out := progress{prefix: "[" + kubeContext + "] "}
+
+	fail := func(err error) FleetResult {
+		out.Warnf("%v", err)
+		result.Err = err
+		result.Error = err.Error()
+		return result
+	}
+
+	clusterCfg := *cfg
+	clusterCfg.Kubernetes.Context = kubeContext
+	analyzer, data, err := loadClusterData(ctx, &clusterCfg, "", out)
+	if err != nil {
+		return fail(err)
+	}
+
+	analysis := runAnalysis(ctx, cfg, analyzer, data, aiClient, out)
+
+	result.ClusterName = data.ClusterName
+	result.CollectedAt = data.CollectedAt
+	result.Health = analysis.Health.Status
+	result.HealthScore = analysis.Health.Score
+	result.Nodes = len(data.Nodes)
+	result.Pods = len(data.Pods)
+	result.ResourceGaps = len(analysis.ResourceGaps)
+	result.OOMEvents = len(analysis.OOMEvents)
+	result.PodsRestarted24h = analysis.PodRestarts.TotalPods24h
+	result.PodsRestarted7d = analysis.PodRestarts.TotalPods7d
+	result.VeleroFailed24h = analysis.VeleroBackups.FailedBackups24h
+	result.NodeIssues = len(analysis.NodeIssues)
+	result.HighRiskNamespaces = countHighRiskNamespaces(analysis.NamespaceAnalysis)
+	for _, e := range data.DataGaps() {
+		result.DataGaps = append(result.DataGaps, e.Source)
+	}
+
+	// Name reports after the context, which is unique in the kubeconfig even when cluster names are not
+	reportFile := filepath.Join(outputDir, autoFileName(kubeContext, data, reportFileExtension(cfg.Output.Format)))
+	if err := writeReport(reportFile, data, analysis, cfg, out); err != nil {
+		return fail(err)
+	}
+	result.ReportFile = reportFile
+
+	out.Printf("✨ Report saved to: %s\n", reportFile)
+	return result
+}
+
+// RenderFleetSummary produces the side-by-side fleet comparison to be written
+// to summaryFile. JSON and YAML formats produce a FleetSummary document;
+// everything else produces Markdown, linking each report relative to
+// summaryFile.
+func RenderFleetSummary(results []FleetResult, cfg *Config, summaryFile string) ([]byte, error) {
+	summary := FleetSummary{
+		SchemaVersion: fleetSchemaVersion,
+		GeneratedAt:   time.Now(),
+		Clusters:      results,
+	}
+
+	switch cfg.Output.Format {
+	case "json":
+		out, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("error encoding JSON fleet summary: %w", err)
+		}
+		return append(out, '\n'), nil
+	case "yaml":
+		out, err := yaml.Marshal(summary)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding YAML fleet summary: %w", err)
+		}
+		return out, nil
+	default:
+		return []byte(generateFleetMarkdown(summary, summaryFile)), nil
+	}
+}
+
+// fleetSummaryExtension returns the file extension for the fleet summary;
+// HTML runs still get a Markdown summary.
+func fleetSummaryExtension(format string) string {
+	if format == "html" {
+		return "md"
+	}
+	return reportFileExtension(format)
+}
+
+// reportLink returns the path of a cluster report relative to the directory of
+// the summary linking to it, or the report path as is when it has none.
+func reportLink(summaryFile, reportFile string) string {
+	summaryDir, err := filepath.Abs(filepath.Dir(summaryFile))
+	if err != nil {
+		return filepath.ToSlash(reportFile)
+	}
+	report, err := filepath.Abs(reportFile)
+	if err != nil {
+		return filepath.ToSlash(reportFile)
+	}
+	link, err := filepath.Rel(summaryDir, report)
+	if err != nil {
+		return filepath.ToSlash(reportFile)
+	}
+	return filepath.ToSlash(link)
+}
+
+func generateFleetMarkdown(summary FleetSummary, summaryFile string) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Kubernetes Fleet Summary\n\n")
+	sb.WriteString(fmt.Sprintf("**Generated:** %s\n\n", summary.GeneratedAt.Format(time.RFC3339)))
+
+	var succeeded, failed []FleetResult
+	for _, r := range summary.Clusters {
+		if r.Error != "" {
+			failed = append(failed, r)
+		} else {
+			succeeded = append(succeeded, r)
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("**Clusters Analyzed:** %d of %d\n\n", len(succeeded), len(summary.Clusters)))
+
+	sb.WriteString("## Cluster Comparison\n\n")
+	if len(succeeded) == 0 {
+		sb.WriteString("No clusters were analyzed successfully.\n\n")
+	} else {
+		sb.WriteString("| Context | Cluster | Health | Nodes | Pods | Resource Gaps | OOM Events | Pods Restarted (24h) | Pods Restarted (7d) | Velero Failures (24h) | Node Issues | High-Risk Namespaces | Report |\n")
+		sb.WriteString("|---------|---------|--------|-------|------|---------------|------------|----------------------|---------------------|-----------------------|-------------|----------------------|--------|\n")
+
+		var total FleetResult
+		for _, r := range succeeded {
+			sb.WriteString(fmt.Sprintf("| `%s` | %s | %s %s (%d) | %d | %d | %d | %d | %d | %d | %d | %d | %d | [%s](%s) |\n",
+				r.Context, r.ClusterName, healthIcon(r.Health), strings.ToUpper(r.Health), r.HealthScore,
+				r.Nodes, r.Pods, r.ResourceGaps, r.OOMEvents, r.PodsRestarted24h, r.PodsRestarted7d,
+				r.VeleroFailed24h, r.NodeIssues, r.HighRiskNamespaces,
+				filepath.Base(r.ReportFile), reportLink(summaryFile, r.ReportFile)))
+
+			total.Nodes += r.Nodes
+			total.Pods += r.Pods
+			total.ResourceGaps += r.ResourceGaps
+			total.OOMEvents += r.OOMEvents
+			total.PodsRestarted24h += r.PodsRestarted24h
+			total.PodsRestarted7d += r.PodsRestarted7d
+			total.VeleroFailed24h += r.VeleroFailed24h
+			total.NodeIssues += r.NodeIssues
+			total.HighRiskNamespaces += r.HighRiskNamespaces
+		}
+
+		sb.WriteString(fmt.Sprintf("| **Total** | | | **%d** | **%d** | **%d** | **%d** | **%d** | **%d** | **%d** | **%d** | **%d** | |\n\n",
+			total.Nodes, total.Pods, total.ResourceGaps, total.OOMEvents, total.PodsRestarted24h,
+			total.PodsRestarted7d, total.VeleroFailed24h, total.NodeIssues, total.HighRiskNamespaces))
+
+		health := make(map[string]int)
+		for _, r := range succeeded {
+			health[r.Health]++
+		}
+		sb.WriteString(fmt.Sprintf("**Health:** 🔴 %d critical · 🟡 %d degraded · 🟢 %d healthy\n\n",
+			health[healthCritical], health[healthDegraded], health[healthHealthy]))
+	}
+
+	var incomplete []FleetResult
+	for _, r := range succeeded {
+		if len(r.DataGaps) > 0 {
+			incomplete = append(incomplete, r)
+		}
+	}
+	if len(incomplete) > 0 {
+		sb.WriteString("## Incomplete Data\n\n")
+		sb.WriteString("These clusters were analyzed, but some data could not be collected and their figures may be understated.\n\n")
+		sb.WriteString("| Context | Missing Sources |\n")
+		sb.WriteString("|---------|-----------------|\n")
+		for _, r := range incomplete {
+			sb.WriteString(fmt.Sprintf("| `%s` | %s |\n", r.Context, strings.Join(r.DataGaps, ", ")))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(failed) > 0 {
+		sb.WriteString("## Failed Clusters\n\n")
+		sb.WriteString("| Context | Error |\n")
+		sb.WriteString("|---------|-------|\n")
+		for _, r := range failed {
+			sb.WriteString(fmt.Sprintf("| `%s` | %s |\n", r.Context, strings.ReplaceAll(r.Error, "|", "\\|")))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func healthIcon(health string) string {
+	switch health {
+	case healthCritical:
+		return "🔴"
+	case healthDegraded:
+		return "🟡"
+	default:
+		return "🟢"
+	}
+}
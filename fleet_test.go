@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestReportLink(t *testing.T) {
+	tests := []struct {
+		name        string
+		summaryFile string
+		reportFile  string
+		want        string
+	}{
+		{"summary next to the reports", "reports/fleet-summary.md", "reports/prod.md", "prod.md"},
+		{"summary above the reports", "fleet.md", "reports/prod.md", "reports/prod.md"},
+		{"summary in another directory", "docs/fleet.md", "reports/prod.md", "../reports/prod.md"},
+		{"absolute paths", "/data/fleet.md", "/data/reports/prod.md", "reports/prod.md"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reportLink(tt.summaryFile, tt.reportFile); got != tt.want {
+				t.Errorf("reportLink(%q, %q) = %q, want %q", tt.summaryFile, tt.reportFile, got, tt.want)
+			}
+		})
+	}
+}
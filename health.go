@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Cluster health statuses, derived from the health score.
+const (
+	healthHealthy  = "healthy"
+	healthDegraded = "degraded"
+	healthCritical = "critical"
+)
+
+// severityPoints is how many points a finding of each severity deducts from
+// its category.
+var severityPoints = map[string]float64{
+	severityCritical: 25,
+	severityHigh:     10,
+	severityMedium:   4,
+	severityLow:      1,
+	severityInfo:     0,
+}
+
+// categoryWeights is the most a category can deduct from the health score.
+// The built-in categories add up to 100, so a category with many findings
+// cannot outweigh the others; categories of other checks deduct up to
+// otherCategoryWeight each.
+var categoryWeights = []struct {
+	category string
+	weight   float64
+}{
+	{categoryNodes, 30},
+	{categoryStability, 25},
+	{categoryResources, 20},
+	{categoryEvents, 10},
+	{categoryBackups, 10},
+	{categoryNamespaces, 5},
+}
+
+const otherCategoryWeight = 10
+
+// nodeDownRules are the node conditions that make the cluster critical
+// whatever its score: nodes that are down. The category caps would otherwise
+// let every node be down and still score 70.
+var nodeDownRules = map[string]bool{
+	ruleNodeNotReady:           true,
+	ruleNodeNetworkUnavailable: true,
+}
+
+// nodePressureRules are the node conditions that keep the cluster from being
+// healthy whatever its score.
+var nodePressureRules = map[string]bool{
+	ruleNodeMemoryPressure: true,
+	ruleNodeDiskPressure:   true,
+	ruleNodePIDPressure:    true,
+}
+
+// HealthScore rates the cluster from 0 to 100 from its findings. Each
+// finding deducts points by severity (critical 25, high 10, medium 4, low 1,
+// info 0) from its category, up to the category's weight, and the score is
+// 100 minus the deductions of all categories.
+type HealthScore struct {
+	Score      int             `json:"score"`  // 100 means no findings
+	Status     string          `json:"status"` // "healthy", "degraded" or "critical"
+	Categories []CategoryScore `json:"categories"`
+}
+
+// CategoryScore is what the findings of one category deduct from the health score.
+type CategoryScore struct {
+	Category string         `json:"category"`
+	Weight   float64        `json:"weight"`   // most the category can deduct
+	Deducted float64        `json:"deducted"` // points deducted, at most Weight
+	Findings int            `json:"findings"`
+	Severity map[string]int `json:"severity,omitempty"` // findings per severity
+}
+
+// healthScore scores the findings. The cluster is critical below 50, with
+// any critical finding or with a node that is down, and degraded below 80 or
+// with a node under pressure.
+func healthScore(findings []Finding) HealthScore {
+	byCategory := make(map[string][]Finding)
+	for _, f := range findings {
+		byCategory[f.Category] = append(byCategory[f.Category], f)
+	}
+
+	var health HealthScore
+	score := func(category string, weight float64) {
+		c := CategoryScore{Category: category, Weight: weight, Findings: len(byCategory[category])}
+		if c.Findings > 0 {
+			c.Severity = countBySeverity(byCategory[category])
+		}
+		for _, f := range byCategory[category] {
+			c.Deducted += severityPoints[f.Severity]
+		}
+		c.Deducted = math.Min(c.Deducted, weight)
+		health.Categories = append(health.Categories, c)
+		delete(byCategory, category)
+	}
+
+	for _, c := range categoryWeights {
+		score(c.category, c.weight)
+	}
+	others := make([]string, 0, len(byCategory))
+	for category := range byCategory {
+		others = append(others, category)
+	}
+	sort.Strings(others)
+	for _, category := range others {
+		score(category, otherCategoryWeight)
+	}
+
+	var deducted float64
+	for _, c := range health.Categories {
+		deducted += c.Deducted
+	}
+	health.Score = int(math.Round(math.Max(0, 100-deducted)))
+
+	switch {
+	case health.Score < 50 || countBySeverity(findings)[severityCritical] > 0 || hasRule(findings, nodeDownRules):
+		health.Status = healthCritical
+	case health.Score < 80 || hasRule(findings, nodePressureRules):
+		health.Status = healthDegraded
+	default:
+		health.Status = healthHealthy
+	}
+	return health
+}
+
+// hasRule reports whether any finding is of one of the rules.
+func hasRule(findings []Finding, rules map[string]bool) bool {
+	for _, f := range findings {
+		if rules[f.RuleID] {
+			return true
+		}
+	}
+	return false
+}
+
+// String describes the health, e.g. "DEGRADED (72/100)". Reports written
+// before the score was introduced have no status.
+func (h HealthScore) String() string {
+	if h.Status == "" {
+		return "NOT RECORDED"
+	}
+	return fmt.Sprintf("%s (%d/100)", strings.ToUpper(h.Status), h.Score)
+}
@@ -0,0 +1,95 @@
+package main
+
+import "testing"
+
+// findingsOf returns n findings of a rule on distinct nodes.
+func findingsOf(ruleID string, n int) []Finding {
+	var findings []Finding
+	for i := 0; i < n; i++ {
+		findings = append(findings, newFinding(ruleID, objectRef("Node", "", string(rune('a'+i))), "", "", nil))
+	}
+	return findings
+}
+
+func TestHealthScore(t *testing.T) {
+	tests := []struct {
+		name       string
+		findings   []Finding
+		wantScore  int
+		wantStatus string
+	}{
+		{
+			name:       "no findings",
+			wantScore:  100,
+			wantStatus: healthHealthy,
+		},
+		{
+			name:       "a low finding",
+			findings:   findingsOf(ruleNodeCordoned, 1),
+			wantScore:  99,
+			wantStatus: healthHealthy,
+		},
+		{
+			name:       "a node under pressure is never healthy",
+			findings:   findingsOf(ruleNodeMemoryPressure, 1),
+			wantScore:  90,
+			wantStatus: healthDegraded,
+		},
+		{
+			name:       "a node down is critical",
+			findings:   findingsOf(ruleNodeNotReady, 1),
+			wantScore:  75,
+			wantStatus: healthCritical,
+		},
+		{
+			name:       "every node down is critical despite the category cap",
+			findings:   append(findingsOf(ruleNodeNotReady, 5), findingsOf(ruleNodeNetworkUnavailable, 5)...),
+			wantScore:  70,
+			wantStatus: healthCritical,
+		},
+		{
+			name:       "a category deducts at most its weight",
+			findings:   findingsOf(ruleMissingRequests, 10),
+			wantScore:  80,
+			wantStatus: healthHealthy,
+		},
+		{
+			name:       "critical below 50",
+			findings:   append(findingsOf(ruleNodeNotReady, 2), findingsOf(ruleOOMKilled, 3)...),
+			wantScore:  45,
+			wantStatus: healthCritical,
+		},
+		{
+			name:       "other categories deduct up to their own weight",
+			findings:   []Finding{{RuleID: "acme/widget", Category: "acme", Severity: severityMedium}, {RuleID: "acme/widget", Category: "acme", Severity: severityHigh}},
+			wantScore:  90,
+			wantStatus: healthHealthy,
+		},
+		{
+			name:       "a critical finding of another check is critical",
+			findings:   []Finding{{RuleID: "acme/widget", Category: "acme", Severity: severityCritical}},
+			wantScore:  90,
+			wantStatus: healthCritical,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := healthScore(tt.findings)
+			if health.Score != tt.wantScore || health.Status != tt.wantStatus {
+				t.Errorf("healthScore() = %d %s, want %d %s", health.Score, health.Status, tt.wantScore, tt.wantStatus)
+			}
+
+			var deducted float64
+			for _, c := range health.Categories {
+				if c.Deducted > c.Weight {
+					t.Errorf("category %s deducted %v, more than its weight %v", c.Category, c.Deducted, c.Weight)
+				}
+				deducted += c.Deducted
+			}
+			if got := 100 - int(deducted); got != health.Score {
+				t.Errorf("categories deduct to %d, score is %d", got, health.Score)
+			}
+		})
+	}
+}
@@ -0,0 +1,660 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+)
+
+// htmlReportData is the view model passed to the HTML report template.
+type htmlReportData struct {
+	Data               *ClusterData
+	Analysis           *Analysis
+	Config             *Config
+	Sections           ReportSectionsConfig
+	GeneratedAt        time.Time
+	HighRiskNamespaces int
+	NotReadyNodes      int
+	OOMRiskNodes       []NodeOOMRisk
+	FindingsSummary    string
+	RuleCounts         []RuleCount
+	OvercommittedKills int // OOM kills on overcommitted nodes
+	NodeKills          int // OOM kills with a known node
+	MetricsAvailable   bool
+	Inventory          []htmlInventoryRow
+	AdditionalFlux     []EventInfo
+	AdditionalWarnings []EventInfo
+	AdditionalRestarts []PodRestart
+}
+
+type htmlInventoryRow struct {
+	PodResourceInfo
+	CPURequest    htmlCell
+	CPULimit      htmlCell
+	MemoryRequest htmlCell
+	MemoryLimit   htmlCell
+}
+
+// htmlCell is a resource value, flagged when it comes from an AI suggestion.
+type htmlCell struct {
+	Value     string
+	Suggested bool
+}
+
+// GenerateHTMLReport renders the analysis as a self-contained HTML document
+// with collapsible sections and sortable, filterable tables.
+func GenerateHTMLReport(data *ClusterData, analysis *Analysis, cfg *Config) (string, error) {
+	view := htmlReportData{
+		Data:               data,
+		Analysis:           analysis,
+		Config:             cfg,
+		Sections:           cfg.ReportSections,
+		GeneratedAt:        time.Now(),
+		HighRiskNamespaces: countHighRiskNamespaces(analysis.NamespaceAnalysis),
+		NotReadyNodes:      len(nodesWith(analysis.NodeConditions, nodeNotReady)),
+		OOMRiskNodes:       atRiskNodes(analysis.NodeOOMRisk),
+		FindingsSummary:    severitySummary(analysis.Findings),
+		RuleCounts:         countByRule(analysis.Findings),
+		MetricsAvailable:   len(data.PodMetrics) > 0,
+		AdditionalFlux:     olderEvents(analysis.FluxEvents.Last24Hours, analysis.FluxEvents.Last48Hours),
+		AdditionalWarnings: olderEvents(analysis.NonFluxEvents.Last24Hours, analysis.NonFluxEvents.Last48Hours),
+		AdditionalRestarts: olderRestarts(analysis.PodRestarts.Last24Hours, analysis.PodRestarts.Last7Days),
+	}
+
+	view.OvercommittedKills, view.NodeKills = oomKillsOnOvercommitted(analysis.NodeOOMRisk)
+
+	for _, info := range buildPodInventory(data, cfg) {
+		suggestion := data.AISuggestions[info.Namespace][suggestionKey(info.Workload, info.ContainerName)]
+		view.Inventory = append(view.Inventory, htmlInventoryRow{
+			PodResourceInfo: info,
+			CPURequest:      suggestedCell(info.CPURequest, suggestion.CPURequest),
+			CPULimit:        suggestedCell(info.CPULimit, suggestion.CPULimit),
+			MemoryRequest:   suggestedCell(info.MemoryRequest, suggestion.MemoryRequest),
+			MemoryLimit:     suggestedCell(info.MemoryLimit, suggestion.MemoryLimit),
+		})
+	}
+
+	tmpl, err := template.New("report").Funcs(template.FuncMap{
+		"upper":     strings.ToUpper,
+		"container": containerLabel,
+		"time": func(t time.Time) string {
+			if t.IsZero() {
+				return "Unknown"
+			}
+			return t.Format("2006-01-02 15:04")
+		},
+		"rfc3339": func(t time.Time) string { return t.Format(time.RFC3339) },
+		"minutes": func(d time.Duration) string {
+			if d <= 0 {
+				return "In Progress"
+			}
+			return fmt.Sprintf("%.1fm", d.Minutes())
+		},
+		"percent": func(part, total int) string {
+			if total == 0 {
+				return "0.0%"
+			}
+			return fmt.Sprintf("%.1f%%", float64(part)/float64(total)*100)
+		},
+		"severityCounts": severityCountSummary,
+		"healthClass": func(health string) string {
+			switch health {
+			case healthCritical:
+				return "risk-critical"
+			case healthDegraded:
+				return "risk-medium"
+			default:
+				return "risk-low"
+			}
+		},
+		"backupClass": func(status string) string {
+			switch status {
+			case "Failed":
+				return "risk-critical"
+			case "PartiallyFailed":
+				return "risk-high"
+			case "Completed":
+				return "risk-low"
+			default:
+				return ""
+			}
+		},
+	}).Parse(htmlReportTemplate)
+	if err != nil {
+		return "", fmt.Errorf("error parsing HTML template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, view); err != nil {
+		return "", fmt.Errorf("error rendering HTML report: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+func suggestedCell(current, suggested string) htmlCell {
+	if current == "Not Set" && suggested != "" && suggested != "KEEP" {
+		return htmlCell{Value: suggested, Suggested: true}
+	}
+	return htmlCell{Value: current}
+}
+
+// olderEvents returns events in the 48h window that are not already listed for the last 24h.
+func olderEvents(last24h, last48h []EventInfo) []EventInfo {
+	recent := make(map[string]bool)
+	for _, e := range last24h {
+		recent[e.Namespace+e.InvolvedObject+e.Reason] = true
+	}
+
+	older := []EventInfo{}
+	for _, e := range last48h {
+		if !recent[e.Namespace+e.InvolvedObject+e.Reason] {
+			older = append(older, e)
+		}
+	}
+	return older
+}
+
+// olderRestarts returns restarts in the 7d window that are not already listed for the last 24h.
+func olderRestarts(last24h, last7d []PodRestart) []PodRestart {
+	recent := make(map[string]bool)
+	for _, r := range last24h {
+		recent[workloadKey(r.Namespace, r.Workload, r.ContainerName)] = true
+	}
+
+	older := []PodRestart{}
+	for _, r := range last7d {
+		if !recent[workloadKey(r.Namespace, r.Workload, r.ContainerName)] {
+			older = append(older, r)
+		}
+	}
+	return older
+}
+
+const htmlReportTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Cluster Analysis - {{.Data.ClusterName}}</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Ubuntu, sans-serif; font-size: 14px; line-height: 1.5; margin: 0 auto; max-width: 1400px; padding: 0 26px 40px; color: #1f2328; }
+h1 { border-bottom: 1px solid #d0d7de; padding-bottom: 0.3em; }
+details { border: 1px solid #d0d7de; border-radius: 6px; margin: 12px 0; padding: 0 16px; }
+details[open] { padding-bottom: 12px; }
+summary { cursor: pointer; font-size: 1.3em; padding: 10px 0; }
+h3 { margin-top: 1.2em; }
+table { border-collapse: collapse; margin: 8px 0; width: 100%; }
+th, td { border: 1px solid #d0d7de; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+table.sortable th { cursor: pointer; user-select: none; }
+table.sortable th::after { color: #8c959f; content: " \2195"; }
+table.sortable th.asc::after { content: " \2191"; }
+table.sortable th.desc::after { content: " \2193"; }
+code, pre { font-family: Menlo, Monaco, Consolas, "Courier New", monospace; }
+pre { background: #f6f8fa; border-radius: 6px; overflow-x: auto; padding: 12px; white-space: pre-wrap; }
+input.filter { border: 1px solid #d0d7de; border-radius: 6px; margin: 6px 0; padding: 6px 10px; width: 320px; }
+.badge { border-radius: 10px; display: inline-block; font-weight: 600; padding: 0 8px; }
+.risk-critical { background: #ffebe9; color: #cf222e; }
+.risk-high { background: #fff1e5; color: #bc4c00; }
+.risk-medium { background: #fff8c5; color: #9a6700; }
+.risk-low { background: #dafbe1; color: #1a7f37; }
+td.suggested { background: #dafbe1; font-weight: 600; }
+td.missing { color: #cf222e; }
+.muted { color: #656d76; }
+.warning { background: #fff8c5; border: 1px solid #d4a72c; border-radius: 6px; padding: 8px 16px; }
+</style>
+</head>
+<body>
+<h1>Kubernetes Cluster Analysis Report</h1>
+<p><strong>Cluster:</strong> <code>{{.Data.ClusterName}}</code><br>
+<strong>Collected:</strong> {{rfc3339 .Data.CollectedAt}}<br>
+<strong>Generated:</strong> {{rfc3339 .GeneratedAt}}
+{{- with .Data.Scope}}<br>
+<strong>Scope:</strong> namespaces {{range $i, $ns := .}}{{if $i}}, {{end}}<code>{{$ns}}</code>{{end}} (namespace-scoped mode; node analysis is skipped){{end}}</p>
+{{- with .Data.DataGaps}}
+<div class="warning"><strong>Incomplete data:</strong> the following sources could not be collected, so related sections may be empty or understated. See Data Coverage below.
+<ul>{{range .}}<li><code>{{.Source}}</code>: {{.Error}}</li>{{end}}</ul></div>
+{{- end}}
+
+{{- with .Analysis.Baseline}}
+<details open>
+<summary>Changes Since Baseline</summary>
+<p>Compared with <code>{{.File}}</code>, collected {{rfc3339 .CollectedAt}}.</p>
+<p><strong>New:</strong> {{len .New}} | <strong>Persisting:</strong> {{.Persisting}} | <strong>Resolved:</strong> {{len .Resolved}}</p>
+{{- if not .New}}
+<p>No new findings since the baseline.</p>
+{{- end}}
+{{- with .New}}
+<h3>New Findings ({{len .}})</h3>
+<table class="sortable">
+<thead><tr><th>Severity</th><th>Rule</th><th>Object</th><th>Container</th><th>Message</th></tr></thead>
+<tbody>
+{{- range .}}
+<tr><td>{{.Severity}}</td><td><code>{{.RuleID}}</code></td><td><code>{{.Object}}</code></td><td>{{or .Container "-"}}</td><td>{{.Message}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- with .Resolved}}
+<h3>Resolved Findings ({{len .}})</h3>
+<table class="sortable">
+<thead><tr><th>Severity</th><th>Rule</th><th>Object</th><th>Container</th><th>Message</th></tr></thead>
+<tbody>
+{{- range .}}
+<tr><td>{{.Severity}}</td><td><code>{{.RuleID}}</code></td><td><code>{{.Object}}</code></td><td>{{or .Container "-"}}</td><td>{{.Message}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+</details>
+{{- end}}
+
+{{- if .Sections.ClusterHealth}}
+<details open>
+<summary>1. Cluster Health Summary</summary>
+<p><strong>Overall Health:</strong> <span class="badge {{healthClass .Analysis.Health.Status}}">{{upper .Analysis.Health.Status}}</span> <strong>{{.Analysis.Health.Score}}</strong>/100</p>
+<table>
+<thead><tr><th>Metric</th><th>Value</th></tr></thead>
+<tbody>
+<tr><td>Total Pods</td><td>{{len .Data.Pods}}</td></tr>
+<tr><td>Total Nodes</td><td>{{len .Data.Nodes}}</td></tr>
+<tr><td>Workload Containers Missing Resources</td><td>{{len .Analysis.ResourceGaps}}</td></tr>
+<tr><td>OOM Events (Recent)</td><td>{{len .Analysis.OOMEvents}}</td></tr>
+<tr><td>Pods with Restarts (24h)</td><td>{{.Analysis.PodRestarts.TotalPods24h}}</td></tr>
+<tr><td>Pods with Restarts (7d)</td><td>{{.Analysis.PodRestarts.TotalPods7d}}</td></tr>
+<tr><td>Nodes Not Ready</td><td>{{.NotReadyNodes}}</td></tr>
+<tr><td>Node Issues</td><td>{{len .Analysis.NodeIssues}}</td></tr>
+<tr><td>Namespaces at Risk</td><td>{{.HighRiskNamespaces}}</td></tr>
+</tbody>
+</table>
+<h3>Health Score Breakdown</h3>
+<p class="muted">Each finding deducts points by severity (critical 25, high 10, medium 4, low 1) from its category, up to the category's weight. The cluster is critical below 50, with any critical finding or with a node that is down, and degraded below 80 or with a node under pressure.</p>
+<table>
+<thead><tr><th>Category</th><th>Findings</th><th>Deducted</th><th>Weight</th></tr></thead>
+<tbody>
+{{- range .Analysis.Health.Categories}}
+<tr><td>{{.Category}}</td><td>{{.Findings}}{{if .Findings}} ({{severityCounts .Severity}}){{end}}</td><td>{{printf "%.0f" .Deducted}}</td><td>{{printf "%.0f" .Weight}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+</details>
+{{- end}}
+
+{{- if .Sections.CriticalIssues}}
+<details open>
+<summary>2. Critical Issues</summary>
+{{- if .Analysis.Findings}}
+<p><strong>Findings:</strong> {{len .Analysis.Findings}} ({{.FindingsSummary}})</p>
+<table class="sortable">
+<thead><tr><th>Rule</th><th>Severity</th><th>Findings</th><th>Title</th></tr></thead>
+<tbody>
+{{- range .RuleCounts}}
+<tr><td><code>{{.Rule.ID}}</code></td><td>{{.Rule.Severity}}</td><td>{{.Findings}}</td><td>{{.Rule.Title}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- if not .Analysis.CriticalIssues}}
+<p>No critical issues detected.</p>
+{{- end}}
+{{- range .Analysis.CriticalIssues}}
+<h3>{{.Title}} <span class="muted">(Priority {{.Priority}})</span></h3>
+<p><strong>Description:</strong> {{.Description}}<br>
+<strong>Impact:</strong> {{.Impact}}<br>
+<strong>Recommendation:</strong> {{.Recommendation}}</p>
+{{- if .Examples}}
+<ul>{{range .Examples}}<li><code>{{.}}</code></li>{{end}}</ul>
+{{- end}}
+{{- end}}
+{{- with .Analysis.SuppressedFindings}}
+<h3>Suppressed Findings ({{len .}})</h3>
+<p class="muted">These findings were accepted by a waiver and are left out of the findings, the health score and the report sections.</p>
+<table class="sortable">
+<thead><tr><th>Rule</th><th>Object</th><th>Container</th><th>Waived By</th><th>Reason</th><th>Owner</th><th>Expires</th></tr></thead>
+<tbody>
+{{- range .}}
+<tr><td><code>{{.RuleID}}</code></td><td><code>{{.Object}}</code></td><td>{{or .Container "-"}}</td><td>{{if eq .WaivedBy "file"}}waivers file{{else}}annotation on <code>{{.WaivedBy}}</code>{{end}}</td><td>{{or .Reason "-"}}</td><td>{{or .Owner "-"}}</td><td>{{or .Expires "-"}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- with .Analysis.ExpiredWaivers}}
+<h3>Expired Waivers ({{len .}})</h3>
+<div class="warning">These waivers are past their expiry date and no longer suppress findings. Renew them with a new date or fix the findings.</div>
+<table>
+<thead><tr><th>Rule</th><th>Scope</th><th>Reason</th><th>Owner</th><th>Expired</th><th>Findings</th></tr></thead>
+<tbody>
+{{- range .}}
+<tr><td><code>{{.Rule}}</code></td><td>{{.Scope}}</td><td>{{.Reason}}</td><td>{{.Owner}}</td><td>{{.Expires}}</td><td>{{.Matches}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+</details>
+{{- end}}
+
+{{- if .Sections.ResourceManagement}}
+<details>
+<summary>3. Resource Management ({{len .Analysis.ResourceGaps}} workload containers missing requests or limits)</summary>
+{{- if .Analysis.ResourceGaps}}
+<input class="filter" type="search" placeholder="Filter workloads..." data-table="gaps-table">
+<table id="gaps-table" class="sortable">
+<thead><tr><th>Namespace</th><th>Workload</th><th>Replicas</th><th>Container</th><th>Missing Requests</th><th>Missing Limits</th></tr></thead>
+<tbody>
+{{- range .Analysis.ResourceGaps}}
+<tr><td>{{.Namespace}}</td><td>{{.Workload}}</td><td>{{.Replicas}}</td><td>{{container .Container .ContainerType}}</td><td>{{if .MissingRequests}}Yes{{else}}No{{end}}</td><td>{{if .MissingLimits}}Yes{{else}}No{{end}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p>All pods have resource requests and limits configured.</p>
+{{- end}}
+{{- if .Analysis.ShortLivedJobs.TotalJobs}}
+<h3>Short-Lived Jobs</h3>
+<p>{{.Analysis.ShortLivedJobs.ShortJobs}} of {{.Analysis.ShortLivedJobs.TotalJobs}} job pods completed in under {{.Config.Thresholds.ShortJobDuration}} minutes ({{percent .Analysis.ShortLivedJobs.ShortJobs .Analysis.ShortLivedJobs.TotalJobs}}).</p>
+{{- end}}
+</details>
+{{- end}}
+
+{{- if .Sections.NodeAnalysis}}
+<details{{if or .Analysis.NodeIssues .Analysis.OOMEvents .Analysis.NodeConditions}} open{{end}}>
+<summary>4. Node Analysis</summary>
+{{- if .Analysis.NodeConditions}}
+<h3>Node Conditions</h3>
+<table class="sortable">
+<thead><tr><th>Node</th><th>Problem</th><th>Since</th><th>Reason</th><th>Taint</th><th>Message</th></tr></thead>
+<tbody>
+{{- range .Analysis.NodeConditions}}
+<tr><td>{{.NodeName}}</td><td>{{.Problem}}</td><td>{{with .Since}}{{time .}}{{else}}-{{end}}</td><td>{{.Reason}}</td><td>{{.Taint}}</td><td>{{.Message}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- if .Analysis.NodePools}}
+<h3>Node Pools</h3>
+<table class="sortable">
+<thead><tr><th>Pool</th><th>Nodes</th><th>CPU Requested (cores)</th><th>CPU Allocatable (cores)</th><th>CPU %</th><th>CPU Min / Max / StdDev</th><th>Memory Requested (GiB)</th><th>Memory Allocatable (GiB)</th><th>Memory %</th><th>Memory Min / Max / StdDev</th><th>CPU Used %</th><th>Memory Used %</th><th>Limits Overcommit (CPU / Memory)</th><th>Outliers</th></tr></thead>
+<tbody>
+{{- range .Analysis.NodePools}}
+<tr><td>{{.Pool}}</td><td>{{.Nodes}}</td><td>{{printf "%.2f" .RequestedCPU}}</td><td>{{printf "%.2f" .AllocatableCPU}}</td><td>{{printf "%.1f" .CPUPercent}}</td><td>{{printf "%.1f / %.1f / %.1f" .CPUSpread.Min .CPUSpread.Max .CPUSpread.StdDev}}</td><td>{{printf "%.2f" .RequestedMemory}}</td><td>{{printf "%.2f" .AllocatableMemory}}</td><td>{{printf "%.1f" .MemoryPercent}}</td><td>{{printf "%.1f / %.1f / %.1f" .MemorySpread.Min .MemorySpread.Max .MemorySpread.StdDev}}</td><td>{{if .MetricsNodes}}{{printf "%.1f" .CPUUsagePercent}}{{else}}-{{end}}</td><td>{{if .MetricsNodes}}{{printf "%.1f" .MemoryUsagePercent}}{{else}}-{{end}}</td><td>{{printf "%.2fx / %.2fx" .CPUOvercommit .MemoryOvercommit}}</td><td>{{range $i, $o := .Outliers}}{{if $i}}<br>{{end}}{{$o}}{{end}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- if .Analysis.NodeUtilization}}
+<h3>Node Utilization</h3>
+<table class="sortable">
+<thead><tr><th>Node</th><th>Pool</th><th>CPU Used (cores)</th><th>CPU Used %</th><th>CPU Used / Requested %</th><th>CPU Limits Overcommit</th><th>Memory Used (GiB)</th><th>Memory Used %</th><th>Memory Used / Requested %</th><th>Memory Limits Overcommit</th><th>Assessment</th></tr></thead>
+<tbody>
+{{- range .Analysis.NodeUtilization}}
+<tr><td>{{.NodeName}}</td><td>{{.Pool}}</td><td>{{printf "%.2f" .UsedCPU}}</td><td>{{printf "%.1f" .CPUUsagePercent}}</td><td>{{printf "%.1f" .CPUUsageOfRequests}}</td><td>{{printf "%.2fx" .CPUOvercommit}}</td><td>{{printf "%.2f" .UsedMemory}}</td><td>{{printf "%.1f" .MemoryUsagePercent}}</td><td>{{printf "%.1f" .MemoryUsageOfRequests}}</td><td>{{printf "%.2fx" .MemoryOvercommit}}</td><td>{{.Assessment}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- if .Analysis.NodeIssues}}
+<table class="sortable">
+<thead><tr><th>Node</th><th>Issue</th><th>Pods</th><th>CPU Requested (cores)</th><th>CPU %</th><th>Memory Requested (GiB)</th><th>Memory %</th><th>CPU Allocatable (cores)</th><th>Memory Allocatable (GiB)</th><th>Top Requesting Pods</th></tr></thead>
+<tbody>
+{{- range .Analysis.NodeIssues}}
+<tr><td>{{.NodeName}}</td><td>{{.Issue}}</td><td>{{.PodCount}}</td><td>{{printf "%.2f" .RequestedCPU}}</td><td>{{printf "%.1f" .CPUPercent}}</td><td>{{printf "%.2f" .RequestedMemory}}</td><td>{{printf "%.1f" .MemoryPercent}}</td><td>{{printf "%.2f" .AllocatableCPU}}</td><td>{{printf "%.2f" .AllocatableMemory}}</td><td>{{range $i, $p := .TopPods}}{{if $i}}<br>{{end}}<code>{{$p.Namespace}}/{{$p.PodName}}</code> ({{printf "%.2f" $p.RequestedCPU}} cores, {{printf "%.2f" $p.RequestedMemory}} GiB){{end}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p>All nodes have healthy resource allocation.</p>
+{{- end}}
+{{- if .OOMRiskNodes}}
+<h3>Node OOM Risk</h3>
+{{- if .NodeKills}}
+<p><strong>{{.OvercommittedKills}} of {{.NodeKills}}</strong> OOM kills with a known node happened on overcommitted nodes.</p>
+{{- end}}
+<table class="sortable">
+<thead><tr><th>Node</th><th>Pool</th><th>Risk</th><th>Memory Limits (GiB)</th><th>Memory Allocatable (GiB)</th><th>Limits Overcommit</th><th>Containers Without Limit</th><th>Memory In Use %</th><th>OOM Kills</th></tr></thead>
+<tbody>
+{{- range .OOMRiskNodes}}
+<tr><td>{{.NodeName}}</td><td>{{.Pool}}</td><td>{{.Risk}}</td><td>{{printf "%.2f" .LimitMemory}}</td><td>{{printf "%.2f" .AllocatableMemory}}</td><td>{{printf "%.2fx" .MemoryOvercommit}}</td><td>{{.UnboundedContainers}}</td><td>{{printf "%.1f" .MemoryPercent}} ({{.MemorySource}})</td><td>{{.OOMKills}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- if .Analysis.OOMEvents}}
+<h3>OOMKilled Events ({{len .Analysis.OOMEvents}})</h3>
+<table class="sortable">
+<thead><tr><th>Timestamp</th><th>Namespace</th><th>Pod</th><th>Container</th><th>Node</th><th>Source</th><th>Exit Code</th><th>Memory Limit</th><th>Last Usage</th></tr></thead>
+<tbody>
+{{- range .Analysis.OOMEvents}}
+<tr><td>{{time .Timestamp}}</td><td>{{.Namespace}}</td><td>{{.PodName}}</td><td>{{.Container}}</td><td>{{.NodeName}}</td><td>{{.Source}}</td><td>{{if .ExitCode}}{{.ExitCode}}{{else}}-{{end}}</td><td>{{or .MemoryLimit "-"}}</td><td>{{or .MemoryUsage "-"}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+</details>
+{{- end}}
+
+{{- if .Sections.PodRestarts}}
+<details>
+<summary>5. Pod Restarts ({{.Analysis.PodRestarts.TotalPods24h}} pods in 24h, {{.Analysis.PodRestarts.TotalPods7d}} in 7d)</summary>
+{{- if .Analysis.PodRestarts.Last7Days}}
+<input class="filter" type="search" placeholder="Filter restarts..." data-table="restarts-table">
+<table id="restarts-table" class="sortable">
+<thead><tr><th>Window</th><th>Namespace</th><th>Workload</th><th>Pods</th><th>Container</th><th>Restarts</th><th>Last Restart</th><th>Reason</th></tr></thead>
+<tbody>
+{{- range .Analysis.PodRestarts.Last24Hours}}
+<tr><td>24h</td><td>{{.Namespace}}</td><td>{{.Workload}}</td><td>{{.Pods}}</td><td>{{.ContainerName}}</td><td>{{.RestartCount}}</td><td>{{time .LastRestartTime}}</td><td>{{.Reason}}</td></tr>
+{{- end}}
+{{- range .AdditionalRestarts}}
+<tr><td>7d</td><td>{{.Namespace}}</td><td>{{.Workload}}</td><td>{{.Pods}}</td><td>{{.ContainerName}}</td><td>{{.RestartCount}}</td><td>{{time .LastRestartTime}}</td><td>{{.Reason}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p>No pod restarts detected in the last 7 days.</p>
+{{- end}}
+</details>
+{{- end}}
+
+{{- if .Sections.FluxEvents}}
+<details>
+<summary>6. Flux Events ({{.Analysis.FluxEvents.Warnings24h}} warnings, {{.Analysis.FluxEvents.Errors24h}} errors in 24h)</summary>
+{{- if .Analysis.FluxEvents.Last48Hours}}
+<input class="filter" type="search" placeholder="Filter Flux events..." data-table="flux-table">
+<table id="flux-table" class="sortable">
+<thead><tr><th>Window</th><th>Type</th><th>Namespace</th><th>Object</th><th>Reason</th><th>Message</th><th>Count</th><th>Last Seen</th></tr></thead>
+<tbody>
+{{- range .Analysis.FluxEvents.Last24Hours}}
+<tr><td>24h</td><td>{{.Type}}</td><td>{{.Namespace}}</td><td>{{.InvolvedObject}}</td><td>{{.Reason}}</td><td>{{.Message}}</td><td>{{.Count}}</td><td>{{time .LastTime}}</td></tr>
+{{- end}}
+{{- range .AdditionalFlux}}
+<tr><td>48h</td><td>{{.Type}}</td><td>{{.Namespace}}</td><td>{{.InvolvedObject}}</td><td>{{.Reason}}</td><td>{{.Message}}</td><td>{{.Count}}</td><td>{{time .LastTime}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p>No Flux events detected in the last 48 hours.</p>
+{{- end}}
+</details>
+{{- end}}
+
+{{- if .Sections.WarningEvents}}
+<details>
+<summary>7. Non-Flux Warning Events ({{.Analysis.NonFluxEvents.Warnings24h}} in 24h, {{.Analysis.NonFluxEvents.Warnings48h}} in 48h)</summary>
+{{- if .Analysis.NonFluxEvents.Last48Hours}}
+<input class="filter" type="search" placeholder="Filter warning events..." data-table="events-table">
+<table id="events-table" class="sortable">
+<thead><tr><th>Window</th><th>Namespace</th><th>Object</th><th>Reason</th><th>Message</th><th>Count</th><th>Last Seen</th></tr></thead>
+<tbody>
+{{- range .Analysis.NonFluxEvents.Last24Hours}}
+<tr><td>24h</td><td>{{.Namespace}}</td><td>{{.InvolvedObject}}</td><td>{{.Reason}}</td><td>{{.Message}}</td><td>{{.Count}}</td><td>{{time .LastTime}}</td></tr>
+{{- end}}
+{{- range .AdditionalWarnings}}
+<tr><td>48h</td><td>{{.Namespace}}</td><td>{{.InvolvedObject}}</td><td>{{.Reason}}</td><td>{{.Message}}</td><td>{{.Count}}</td><td>{{time .LastTime}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p>No warning events detected in the last 48 hours.</p>
+{{- end}}
+</details>
+{{- end}}
+
+{{- if .Sections.VeleroBackups}}
+<details{{if .Analysis.VeleroBackups.FailedBackups24h}} open{{end}}>
+<summary>8. Velero Backups ({{.Analysis.VeleroBackups.TotalBackups24h}} in 24h, {{.Analysis.VeleroBackups.FailedBackups24h}} failed)</summary>
+{{- if .Analysis.VeleroBackups.Last48Hours}}
+<table class="sortable">
+<thead><tr><th>Backup</th><th>Status</th><th>Start Time</th><th>Duration</th><th>Errors</th><th>Warnings</th></tr></thead>
+<tbody>
+{{- range .Analysis.VeleroBackups.Last48Hours}}
+<tr><td>{{.Name}}</td><td><span class="badge {{backupClass .Status}}">{{.Status}}</span></td><td>{{time .StartTime}}</td><td>{{minutes .Duration}}</td><td>{{.Errors}}</td><td>{{.Warnings}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p>No Velero backups detected in the last 48 hours.</p>
+{{- end}}
+</details>
+{{- end}}
+
+{{- if .Sections.RabbitMQStability}}
+<details>
+<summary>9. RabbitMQ Stability ({{len .Analysis.RabbitMQFindings.RabbitMQPods}} pods)</summary>
+{{- if .Analysis.RabbitMQFindings.RabbitMQPods}}
+<ul>{{range .Analysis.RabbitMQFindings.RabbitMQPods}}<li><code>{{.}}</code></li>{{end}}</ul>
+<p>Priority Class Configured: <strong>{{.Analysis.RabbitMQFindings.HasPriorityClass}}</strong><br>
+Resource Limits Set: <strong>{{.Analysis.RabbitMQFindings.HasResourceLimits}}</strong></p>
+<p>Recommended resources: requests <code>cpu: {{.Config.RabbitMQ.RecommendedResources.Requests.CPU}}</code>, <code>memory: {{.Config.RabbitMQ.RecommendedResources.Requests.Memory}}</code>;
+limits <code>cpu: {{.Config.RabbitMQ.RecommendedResources.Limits.CPU}}</code>, <code>memory: {{.Config.RabbitMQ.RecommendedResources.Limits.Memory}}</code>;
+priority class value <code>{{.Config.Thresholds.CriticalPriority}}</code>.</p>
+{{- else}}
+<p>No RabbitMQ pods detected in the cluster.</p>
+{{- end}}
+</details>
+{{- end}}
+
+{{- if .Sections.NamespaceAnalysis}}
+<details open>
+<summary>10. Namespace Analysis</summary>
+{{- if .Analysis.NamespaceAnalysis}}
+<table class="sortable">
+<thead><tr><th>Namespace</th><th>Risk Level</th><th>Total Pods</th><th>Missing Requests</th><th>Missing Limits</th><th>Affected</th></tr></thead>
+<tbody>
+{{- range .Analysis.NamespaceAnalysis}}
+<tr><td>{{.Namespace}}</td><td><span class="badge risk-{{.RiskLevel}}">{{upper .RiskLevel}}</span></td><td>{{.TotalPods}}</td><td>{{.PodsWithoutRequests}}</td><td>{{.PodsWithoutLimits}}</td><td>{{percent .PodsWithoutRequests .TotalPods}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p>No application namespaces found. Adjust <code>filters.app_namespaces</code> in the config to select them.</p>
+{{- end}}
+{{- with .Analysis.ExcludedNamespaces}}
+<h3>Excluded Namespaces ({{len .}})</h3>
+<table class="sortable">
+<thead><tr><th>Namespace</th><th>Reason</th></tr></thead>
+<tbody>
+{{- range .}}
+<tr><td>{{.Namespace}}</td><td>{{.Reason}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+</details>
+{{- end}}
+
+{{- if and .Sections.AIInsights .Analysis.AIInsights}}
+<details open>
+<summary>11. AI-Enhanced Insights</summary>
+<pre>{{.Analysis.AIInsights.Summary}}</pre>
+{{- if .Analysis.AIInsights.AutomationSuggestions}}
+<h3>Automation Suggestions</h3>
+<ul>{{range .Analysis.AIInsights.AutomationSuggestions}}<li>{{.}}</li>{{end}}</ul>
+{{- end}}
+</details>
+{{- end}}
+
+{{- if .Sections.Appendix}}
+<details>
+<summary>Appendix: Data Coverage{{with .Analysis.SkippedAnalyses}} ({{len .}} analyses skipped){{end}}</summary>
+<table>
+<thead><tr><th>Source</th><th>Status</th><th>Items</th><th>Detail</th></tr></thead>
+<tbody>
+{{- range .Data.Coverage}}
+<tr><td><code>{{.Source}}</code></td><td>{{.Status}}</td><td>{{.Items}}</td><td>{{.Detail}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- with .Analysis.DisabledChecks}}
+<p><strong>Disabled checks</strong> (not run by configuration): {{range $i, $c := .}}{{if $i}}, {{end}}<code>{{$c}}</code>{{end}}</p>
+{{- end}}
+{{- if .Analysis.SkippedAnalyses}}
+<p>The following analyses were skipped because their input could not be collected; their sections are empty rather than clean.</p>
+<table>
+<thead><tr><th>Check</th><th>Analysis</th><th>Missing Source</th><th>Reason</th></tr></thead>
+<tbody>
+{{- range .Analysis.SkippedAnalyses}}
+<tr><td><code>{{.Check}}</code></td><td>{{.Analysis}}</td><td><code>{{.Source}}</code></td><td>{{.Reason}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p class="muted">All analyses ran with complete input data.</p>
+{{- end}}
+</details>
+<details>
+<summary>Appendix: All Active Pods ({{len .Inventory}} containers)</summary>
+<p class="muted">Events processed: {{len .Data.Events}}.
+{{- if .MetricsAvailable}} Metrics available for {{len .Data.PodMetrics}} pods.{{else}} Metrics-server unavailable; usage shows N/A.{{end}}
+{{- if .Data.AISuggestions}} Highlighted cells are AI-suggested values for missing settings.{{end}}</p>
+<input class="filter" type="search" placeholder="Filter pods..." data-table="inventory-table">
+<table id="inventory-table" class="sortable">
+<thead><tr><th>Namespace</th><th>Pod</th><th>Container</th><th>CPU Req</th><th>CPU Limit</th><th>CPU Usage</th><th>Mem Req</th><th>Mem Limit</th><th>Mem Usage</th><th>Status</th></tr></thead>
+<tbody>
+{{- range .Inventory}}
+<tr><td>{{.Namespace}}</td><td>{{.PodName}}</td><td>{{container .ContainerName .ContainerType}}</td>
+{{- template "cell" .CPURequest}}{{template "cell" .CPULimit}}<td>{{.CurrentCPU}}</td>
+{{- template "cell" .MemoryRequest}}{{template "cell" .MemoryLimit}}<td>{{.CurrentMemory}}</td><td>{{.Status}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+</details>
+{{- end}}
+
+<script>
+(function () {
+  function cellValue(row, index) {
+    var text = row.cells[index].textContent.trim();
+    var num = parseFloat(text);
+    return isNaN(num) || !/^[\d.]/.test(text) ? text.toLowerCase() : num;
+  }
+  document.querySelectorAll("table.sortable").forEach(function (table) {
+    table.querySelectorAll("th").forEach(function (th, index) {
+      th.addEventListener("click", function () {
+        var asc = !th.classList.contains("asc");
+        table.querySelectorAll("th").forEach(function (h) { h.classList.remove("asc", "desc"); });
+        th.classList.add(asc ? "asc" : "desc");
+        var body = table.tBodies[0];
+        Array.from(body.rows).sort(function (a, b) {
+          var x = cellValue(a, index), y = cellValue(b, index);
+          return (x < y ? -1 : x > y ? 1 : 0) * (asc ? 1 : -1);
+        }).forEach(function (row) { body.appendChild(row); });
+      });
+    });
+  });
+  document.querySelectorAll("input.filter").forEach(function (input) {
+    var table = document.getElementById(input.dataset.table);
+    input.addEventListener("input", function () {
+      var terms = input.value.toLowerCase().split(/\s+/).filter(Boolean);
+      Array.from(table.tBodies[0].rows).forEach(function (row) {
+        var text = row.textContent.toLowerCase();
+        row.style.display = terms.every(function (t) { return text.indexOf(t) !== -1; }) ? "" : "none";
+      });
+    });
+  });
+})();
+</script>
+</body>
+</html>
+{{define "cell"}}<td class="{{if .Suggested}}suggested{{else if eq .Value "Not Set"}}missing{{end}}">{{.Value}}</td>{{end}}`
@@ -2,11 +2,10 @@ package main
 
 import (
 	"context"
-	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
@@ -15,179 +14,272 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/util/homedir"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 func main() {
-	var kubeconfig *string
-	if home := homedir.HomeDir(); home != "" {
-		kubeconfig = flag.String("kubeconfig", filepath.Join(home, ".kube", "config"), "(optional) absolute path to the kubeconfig file")
-	} else {
-		kubeconfig = flag.String("kubeconfig", "", "absolute path to the kubeconfig file")
+	// Without a subcommand, behave like earlier releases and run the full analysis
+	name, args := "analyze", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+
+	if name == "help" {
+		if len(args) > 0 {
+			if cmd := findCommand(args[0]); cmd != nil {
+				cmd.newFlagSet().Usage()
+				return
+			}
+		}
+		printUsage()
+		return
+	}
+
+	cmd := findCommand(name)
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", name)
+		printUsage()
+		os.Exit(2)
+	}
+
+	if err := cmd.run(cmd, args); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
+
+// progress prints status lines, prefixed with the context name in multi-cluster runs.
+// Commands that write their result to stdout send progress to stderr instead.
+type progress struct {
+	prefix string
+	w      io.Writer
+}
+
+func (p progress) Printf(format string, args ...interface{}) {
+	w := p.w
+	if w == nil {
+		w = os.Stdout
 	}
+	fmt.Fprintf(w, p.prefix+format, args...)
+}
 
-	outputFile := flag.String("output", "cluster-analysis-report.md", "output file path for the analysis report")
-	aiProvider := flag.String("ai-provider", "openai", "AI provider (openai or azure)")
-	aiEndpoint := flag.String("ai-endpoint", "", "AI endpoint URL (for Azure OpenAI)")
-	aiModel := flag.String("ai-model", "gpt-4o", "AI model to use (gpt-4o, gpt-4o-mini, gpt-4-turbo, etc.)")
-	flag.Parse()
+func (p progress) Warnf(format string, args ...interface{}) {
+	log.Printf(p.prefix+"Warning: "+format, args...)
+}
 
-	// Build kubernetes client
-	config, err := buildConfig(*kubeconfig)
+// newClusterAnalyzer builds Kubernetes clients for the given connection settings and wraps them in an Analyzer.
+func newClusterAnalyzer(cfg *Config, conn KubernetesConfig) (*Analyzer, error) {
+	config, err := buildConfig(conn)
 	if err != nil {
-		log.Fatalf("Error building kubeconfig: %v", err)
+		return nil, fmt.Errorf("Error building kubeconfig: %w", err)
 	}
 
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
-		log.Fatalf("Error creating kubernetes client: %v", err)
+		return nil, fmt.Errorf("Error creating kubernetes client: %w", err)
 	}
 
 	dynamicClient, err := dynamic.NewForConfig(config)
 	if err != nil {
-		log.Fatalf("Error creating dynamic client: %v", err)
+		return nil, fmt.Errorf("Error creating dynamic client: %w", err)
 	}
 
-	ctx := context.Background()
+	return NewAnalyzer(clientset, dynamicClient, cfg), nil
+}
 
-	fmt.Println("🔍 Analyzing Kubernetes cluster...")
+// aiAPIKey returns the AI API key from the environment, if any.
+func aiAPIKey() string {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		apiKey = os.Getenv("AZURE_OPENAI_API_KEY")
+	}
+	return apiKey
+}
+
+// newAIClientFromEnv creates an AI client when an API key is present in the environment.
+func newAIClientFromEnv(cfg *Config, out progress) *AIClient {
+	apiKey := aiAPIKey()
+	if apiKey == "" {
+		log.Println("⚠️  No AI API key found. Skipping AI-enhanced analysis.")
+		log.Println("   Set OPENAI_API_KEY or AZURE_OPENAI_API_KEY environment variable to enable AI analysis.")
+		return nil
+	}
 
-	// Initialize analyzer
-	analyzer := NewAnalyzer(clientset, dynamicClient)
+	out.Printf("🤖 Initializing AI analysis...\n")
+	aiClient, err := NewAIClient(apiKey, cfg)
+	if err != nil {
+		out.Warnf("Could not initialize AI client: %v", err)
+		return nil
+	}
+	return aiClient
+}
+
+// loadClusterData collects data from the cluster, or reads it from a snapshot
+// when fromSnapshot is set, and returns an Analyzer for it.
+func loadClusterData(ctx context.Context, cfg *Config, fromSnapshot string, out progress) (*Analyzer, *ClusterData, error) {
+	if fromSnapshot != "" {
+		// Replay previously captured data; no API server access is needed
+		out.Printf("📂 Loading cluster data from snapshot %s...\n", fromSnapshot)
+		data, err := ReadSnapshot(fromSnapshot)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error reading snapshot: %w", err)
+		}
+		out.Printf("✅ Loaded snapshot of %s captured at %s\n",
+			data.ClusterName, data.CollectedAt.Format(time.RFC3339))
+		return NewAnalyzer(nil, nil, cfg), data, nil
+	}
+
+	analyzer, err := newClusterAnalyzer(cfg, cfg.Kubernetes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out.Printf("🔍 Analyzing Kubernetes cluster...\n")
 
 	// Collect cluster data
-	fmt.Println("📊 Collecting cluster data...")
+	out.Printf("📊 Collecting cluster data...\n")
 	data, err := analyzer.CollectClusterData(ctx)
 	if err != nil {
-		log.Fatalf("Error collecting cluster data: %v", err)
+		return nil, nil, fmt.Errorf("Error collecting cluster data: %w", err)
 	}
 
-	fmt.Printf("✅ Collected data: %d pods, %d nodes, %d events\n",
+	out.Printf("✅ Collected data: %d pods, %d nodes, %d events\n",
 		len(data.Pods), len(data.Nodes), len(data.Events))
+	for _, e := range data.CollectionErrors {
+		if e.Reason == reasonNotInstalled || e.Reason == reasonOutOfScope {
+			out.Printf("ℹ️  Skipping %s: %s\n", e.Source, e.Error)
+			continue
+		}
+		out.Warnf("could not collect %s (%s): %s", e.Source, e.Reason, e.Error)
+	}
+	return analyzer, data, nil
+}
 
+// runAnalysis analyzes collected data and, when an AI client is available,
+// adds AI insights and per-namespace resource suggestions.
+func runAnalysis(ctx context.Context, cfg *Config, analyzer *Analyzer, data *ClusterData, aiClient *AIClient, out progress) *Analysis {
 	// Analyze cluster data
-	fmt.Println("🔬 Analyzing cluster resources...")
+	out.Printf("🔬 Analyzing cluster resources...\n")
 	analysis := analyzer.AnalyzeCluster(data)
 
-	// Get AI API key from environment
-	apiKey := os.Getenv("OPENAI_API_KEY")
-	if apiKey == "" {
-		apiKey = os.Getenv("AZURE_OPENAI_API_KEY")
+	if aiClient == nil {
+		return analysis
 	}
 
-	// Initialize AI client
-	var aiClient *AIClient
-	if apiKey != "" {
-		fmt.Println("🤖 Initializing AI analysis...")
-		aiClient, err = NewAIClient(apiKey, *aiProvider, *aiEndpoint, *aiModel)
-		if err != nil {
-			log.Printf("Warning: Could not initialize AI client: %v", err)
-		}
+	// Generate AI insights
+	out.Printf("💡 Generating AI insights...\n")
+	aiInsights, err := aiClient.AnalyzeCluster(ctx, data, analysis)
+	if err != nil {
+		out.Warnf("AI analysis failed: %v", err)
 	} else {
-		log.Println("⚠️  No AI API key found. Skipping AI-enhanced analysis.")
-		log.Println("   Set OPENAI_API_KEY or AZURE_OPENAI_API_KEY environment variable to enable AI analysis.")
+		analysis.AIInsights = aiInsights
 	}
 
-	// Generate AI insights
-	if aiClient != nil {
-		fmt.Println("💡 Generating AI insights...")
-		aiInsights, err := aiClient.AnalyzeCluster(ctx, data, analysis)
-		if err != nil {
-			log.Printf("Warning: AI analysis failed: %v", err)
-		} else {
-			analysis.AIInsights = aiInsights
-		}
+	generateAISuggestions(ctx, cfg, data, aiClient, out)
 
-		// Generate AI resource suggestions for ALL namespaces with missing resources
-		fmt.Println("🎯 Generating AI resource suggestions for all namespaces with missing resources...")
-		data.AISuggestions = make(map[string]map[string]ResourceSuggestion)
+	return analysis
+}
+
+// generateAISuggestions asks the AI for resource values in every namespace with missing resources.
+func generateAISuggestions(ctx context.Context, cfg *Config, data *ClusterData, aiClient *AIClient, out progress) {
+	out.Printf("🎯 Generating AI resource suggestions for all namespaces with missing resources...\n")
+	data.AISuggestions = make(map[string]map[string]ResourceSuggestion)
 
-		// Find all namespaces that have pods with missing resources
-		namespacesWithMissingResources := make(map[string]bool)
-		for _, pod := range data.Pods {
-			if pod.Status.Phase != corev1.PodRunning {
-				continue
+	// Find all namespaces that have pods with missing resources
+	namespacesWithMissingResources := make(map[string]bool)
+	for _, pod := range data.Pods {
+		if pod.Status.Phase != corev1.PodRunning || !cfg.Filters.Includes(pod.Namespace) {
+			continue
+		}
+		for _, container := range resourceContainers(&pod) {
+			hasMissingResources := false
+			if container.Resources.Requests == nil {
+				hasMissingResources = true
 			}
-			for _, container := range pod.Spec.Containers {
-				hasMissingResources := false
-				if container.Resources.Requests == nil {
+			if container.Resources.Limits == nil {
+				hasMissingResources = true
+			} else if container.Resources.Requests != nil {
+				if _, ok := container.Resources.Requests[corev1.ResourceCPU]; !ok {
 					hasMissingResources = true
 				}
-				if container.Resources.Limits == nil {
+				if _, ok := container.Resources.Requests[corev1.ResourceMemory]; !ok {
 					hasMissingResources = true
-				} else if container.Resources.Requests != nil {
-					if _, ok := container.Resources.Requests[corev1.ResourceCPU]; !ok {
-						hasMissingResources = true
-					}
-					if _, ok := container.Resources.Requests[corev1.ResourceMemory]; !ok {
-						hasMissingResources = true
-					}
-					if _, ok := container.Resources.Limits[corev1.ResourceCPU]; !ok {
-						hasMissingResources = true
-					}
-					if _, ok := container.Resources.Limits[corev1.ResourceMemory]; !ok {
-						hasMissingResources = true
-					}
 				}
-				if hasMissingResources {
-					namespacesWithMissingResources[pod.Namespace] = true
-					break
+				if _, ok := container.Resources.Limits[corev1.ResourceCPU]; !ok {
+					hasMissingResources = true
 				}
+				if _, ok := container.Resources.Limits[corev1.ResourceMemory]; !ok {
+					hasMissingResources = true
+				}
+			}
+			if hasMissingResources {
+				namespacesWithMissingResources[pod.Namespace] = true
+				break
 			}
 		}
+	}
 
-		fmt.Printf("   Found %d namespaces with missing resource configurations\n", len(namespacesWithMissingResources))
+	out.Printf("   Found %d namespaces with missing resource configurations\n", len(namespacesWithMissingResources))
 
-		// Generate suggestions for each namespace
-		for ns := range namespacesWithMissingResources {
-			suggestions, err := aiClient.SuggestResourceLimits(ctx, collectPodResourceInfoForNamespace(data.Pods, data.PodMetrics, ns), ns)
-			if err != nil {
-				log.Printf("Warning: AI resource suggestion failed for namespace %s: %v", ns, err)
-			} else if len(suggestions) > 0 {
-				data.AISuggestions[ns] = suggestions
-				fmt.Printf("   ✅ Generated suggestions for %d pods in namespace '%s'\n", len(suggestions), ns)
-			}
+	// Generate suggestions for each namespace
+	for ns := range namespacesWithMissingResources {
+		suggestions, err := aiClient.SuggestResourceLimits(ctx, mergeResourceInfo(collectPodResourceInfoForNamespace(data, ns)), ns)
+		if err != nil {
+			out.Warnf("AI resource suggestion failed for namespace %s: %v", ns, err)
+		} else if len(suggestions) > 0 {
+			data.AISuggestions[ns] = suggestions
+			out.Printf("   ✅ Generated suggestions for %d workload containers in namespace '%s'\n", len(suggestions), ns)
 		}
 	}
+}
 
-	// Generate output filename based on cluster name and timestamp
-	timestamp := time.Now().Format("20060102")
-	sanitizedClusterName := strings.ReplaceAll(data.ClusterName, "/", "-")
-	sanitizedClusterName = strings.ReplaceAll(sanitizedClusterName, ":", "-")
-	autoOutputFile := fmt.Sprintf("%s-%s.md", sanitizedClusterName, timestamp)
+// autoFileName builds "<name>-YYYYMMDD.<ext>" from the cluster (or context) name and collection date.
+func autoFileName(name string, data *ClusterData, ext string) string {
+	timestamp := data.CollectedAt.Format("20060102")
+	sanitized := strings.ReplaceAll(name, "/", "-")
+	sanitized = strings.ReplaceAll(sanitized, ":", "-")
+	return fmt.Sprintf("%s-%s.%s", sanitized, timestamp, ext)
+}
 
-	// Use auto-generated filename unless user specified a custom one
-	finalOutputFile := *outputFile
-	if *outputFile == "cluster-analysis-report.md" {
-		finalOutputFile = autoOutputFile
+// reportOutputPath returns the configured report file, or an auto-generated
+// "<cluster>-YYYYMMDD.<ext>" name when none was given.
+func reportOutputPath(data *ClusterData, cfg *Config) string {
+	if cfg.Output.File == "" || cfg.Output.File == "cluster-analysis-report.md" {
+		return autoFileName(data.ClusterName, data, reportFileExtension(cfg.Output.Format))
 	}
+	return cfg.Output.File
+}
 
-	// Generate report
-	fmt.Println("📝 Generating report...")
-	report := GenerateReport(data, analysis)
-
-	// Write report to file
-	err = os.WriteFile(finalOutputFile, []byte(report), 0644)
+// writeReport renders the analysis in the configured format and writes it to path.
+func writeReport(path string, data *ClusterData, analysis *Analysis, cfg *Config, out progress) error {
+	out.Printf("📝 Generating report...\n")
+	report, err := RenderReport(data, analysis, cfg)
 	if err != nil {
-		log.Fatalf("Error writing report: %v", err)
+		return fmt.Errorf("Error generating report: %w", err)
 	}
 
-	fmt.Printf("✨ Analysis complete! Report saved to: %s\n", finalOutputFile)
+	if err := os.WriteFile(path, report, 0644); err != nil {
+		return fmt.Errorf("Error writing report: %w", err)
+	}
+	return nil
 }
 
-func collectPodResourceInfoForNamespace(pods []corev1.Pod, podMetrics map[string]PodMetrics, namespace string) []PodResourceInfo {
+func collectPodResourceInfoForNamespace(data *ClusterData, namespace string) []PodResourceInfo {
 	var podInfos []PodResourceInfo
+	workloads := newWorkloadResolver(data)
 
-	for _, pod := range pods {
+	for _, pod := range data.Pods {
 		if pod.Namespace != namespace || pod.Status.Phase != corev1.PodRunning {
 			continue
 		}
 
-		for _, container := range pod.Spec.Containers {
+		workload := workloads.resolve(&pod)
+		for _, container := range resourceContainers(&pod) {
 			podInfo := PodResourceInfo{
 				Namespace:     pod.Namespace,
+				Workload:      workload,
 				PodName:       pod.Name,
 				ContainerName: container.Name,
+				ContainerType: container.Type,
 				Status:        string(pod.Status.Phase),
 			}
 
@@ -226,7 +318,7 @@ func collectPodResourceInfoForNamespace(pods []corev1.Pod, podMetrics map[string
 
 			// Get actual usage from metrics if available
 			podKey := pod.Namespace + "/" + pod.Name
-			if podMetric, ok := podMetrics[podKey]; ok {
+			if podMetric, ok := data.PodMetrics[podKey]; ok {
 				if containerMetric, ok := podMetric.Containers[container.Name]; ok {
 					podInfo.CurrentCPU = containerMetric.CPUUsage
 					podInfo.CurrentMemory = containerMetric.MemoryUsage
@@ -247,17 +339,50 @@ func collectPodResourceInfoForNamespace(pods []corev1.Pod, podMetrics map[string
 	return podInfos
 }
 
-func buildConfig(kubeconfig string) (*rest.Config, error) {
-	// Try in-cluster config first
-	config, err := rest.InClusterConfig()
-	if err == nil {
-		return config, nil
+// buildConfig resolves a rest.Config the way kubectl does: the standard
+// kubeconfig loading rules plus command-line overrides, falling back to the
+// in-cluster service account when no kubeconfig is available.
+func buildConfig(conn KubernetesConfig) (*rest.Config, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = conn.Kubeconfig
+
+	overrides := &clientcmd.ConfigOverrides{
+		CurrentContext: conn.Context,
+		Context: clientcmdapi.Context{
+			Cluster:  conn.Cluster,
+			AuthInfo: conn.User,
+		},
+		ClusterInfo: clientcmdapi.Cluster{Server: conn.Server},
+		AuthInfo: clientcmdapi.AuthInfo{
+			Impersonate:       conn.As,
+			ImpersonateGroups: conn.AsGroups,
+		},
+		Timeout: conn.RequestTimeout,
 	}
 
-	// Fall back to kubeconfig
-	if kubeconfig == "" {
-		return nil, fmt.Errorf("unable to load in-cluster config and no kubeconfig provided")
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	config.QPS = conn.QPS
+	config.Burst = conn.Burst
+
+	// The in-cluster fallback only honors the server override, so apply
+	// impersonation and the timeout explicitly
+	if conn.As != "" {
+		config.Impersonate = rest.ImpersonationConfig{
+			UserName: conn.As,
+			Groups:   conn.AsGroups,
+		}
+	}
+	if conn.RequestTimeout != "" {
+		timeout, err := parseRequestTimeout(conn.RequestTimeout)
+		if err != nil {
+			return nil, err
+		}
+		config.Timeout = timeout
 	}
 
-	return clientcmd.BuildConfigFromFlags("", kubeconfig)
+	return config, nil
 }
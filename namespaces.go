@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ExcludedNamespace is a namespace left out of the namespace risk analysis.
+type ExcludedNamespace struct {
+	Namespace string `json:"namespace"`
+	Reason    string `json:"reason"`
+}
+
+// namespaceSelector applies the namespace filters and the application
+// namespace selection to namespace objects.
+type namespaceSelector struct {
+	filters         FiltersConfig
+	pattern         *regexp.Regexp
+	labels          labels.Selector
+	annotationKey   string
+	annotationValue string
+	annotationSet   bool // annotation given as key=value rather than key only
+}
+
+// newNamespaceSelector compiles the application namespace selection. Errors
+// name the offending field, relative to filters.app_namespaces.
+func newNamespaceSelector(filters FiltersConfig) (*namespaceSelector, error) {
+	s := &namespaceSelector{filters: filters}
+	app := filters.AppNamespaces
+
+	if app.NamePattern != "" {
+		pattern, err := regexp.Compile(app.NamePattern)
+		if err != nil {
+			return nil, fmt.Errorf("name_pattern: %w", err)
+		}
+		s.pattern = pattern
+	}
+
+	if app.LabelSelector != "" {
+		selector, err := labels.Parse(app.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("label_selector: %w", err)
+		}
+		s.labels = selector
+	}
+
+	if app.Annotation != "" {
+		key, value, hasValue := strings.Cut(app.Annotation, "=")
+		if key == "" {
+			return nil, fmt.Errorf("annotation: expected \"key\" or \"key=value\", got %q", app.Annotation)
+		}
+		s.annotationKey, s.annotationValue, s.annotationSet = key, value, hasValue
+	}
+
+	return s, nil
+}
+
+// exclusionReason explains why a namespace is not analyzed, or returns "" if it is.
+func (s *namespaceSelector) exclusionReason(ns corev1.Namespace) string {
+	for _, name := range s.filters.ExcludeNamespaces {
+		if name == ns.Name {
+			return "listed in filters.exclude_namespaces"
+		}
+	}
+	if !s.filters.Includes(ns.Name) {
+		return "not listed in filters.include_namespaces"
+	}
+
+	if !s.filters.AppNamespacesOnly {
+		return ""
+	}
+	if s.pattern != nil && !s.pattern.MatchString(ns.Name) {
+		return fmt.Sprintf("name does not match %q", s.filters.AppNamespaces.NamePattern)
+	}
+	if s.labels != nil && !s.labels.Matches(labels.Set(ns.Labels)) {
+		return fmt.Sprintf("labels do not match %q", s.filters.AppNamespaces.LabelSelector)
+	}
+	if s.annotationKey != "" {
+		value, ok := ns.Annotations[s.annotationKey]
+		if !ok {
+			return fmt.Sprintf("missing annotation %q", s.annotationKey)
+		}
+		if s.annotationSet && value != s.annotationValue {
+			return fmt.Sprintf("annotation %q is %q, not %q", s.annotationKey, value, s.annotationValue)
+		}
+	}
+	return ""
+}
+
+// selectNamespaces splits namespaces into those selected for the risk
+// analysis and those excluded, with the reason for each exclusion.
+func (s *namespaceSelector) selectNamespaces(namespaces []corev1.Namespace) ([]string, []ExcludedNamespace) {
+	var selected []string
+	var excluded []ExcludedNamespace
+	for _, ns := range namespaces {
+		if reason := s.exclusionReason(ns); reason != "" {
+			excluded = append(excluded, ExcludedNamespace{Namespace: ns.Name, Reason: reason})
+			continue
+		}
+		selected = append(selected, ns.Name)
+	}
+	return selected, excluded
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testNamespace(name string, labels, annotations map[string]string) corev1.Namespace {
+	return corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations}}
+}
+
+func TestSelectNamespaces(t *testing.T) {
+	namespaces := []corev1.Namespace{
+		testNamespace("app", map[string]string{"tier": "application"}, map[string]string{"analyzer/app": "true"}),
+		testNamespace("web", map[string]string{"tier": "application", "team": "platform"}, nil),
+		testNamespace("kube-system", map[string]string{"tier": "system"}, nil),
+		testNamespace("ops", nil, map[string]string{"analyzer/app": "false"}),
+	}
+
+	tests := []struct {
+		name         string
+		filters      FiltersConfig
+		wantSelected []string
+		wantExcluded map[string]string // namespace -> reason
+	}{
+		{
+			name:         "default name pattern",
+			filters:      DefaultConfig().Filters,
+			wantSelected: []string{"app", "web", "ops"},
+			wantExcluded: map[string]string{"kube-system": `name does not match "^[a-z0-9]{3}$"`},
+		},
+		{
+			name:         "selection disabled",
+			filters:      FiltersConfig{AppNamespaces: AppNamespaceSelector{NamePattern: "^$"}},
+			wantSelected: []string{"app", "web", "kube-system", "ops"},
+			wantExcluded: map[string]string{},
+		},
+		{
+			name: "label selector",
+			filters: FiltersConfig{
+				AppNamespacesOnly: true,
+				AppNamespaces:     AppNamespaceSelector{LabelSelector: "tier=application,team!=platform"},
+			},
+			wantSelected: []string{"app"},
+			wantExcluded: map[string]string{
+				"web":         `labels do not match "tier=application,team!=platform"`,
+				"kube-system": `labels do not match "tier=application,team!=platform"`,
+				"ops":         `labels do not match "tier=application,team!=platform"`,
+			},
+		},
+		{
+			name: "annotation key and value",
+			filters: FiltersConfig{
+				AppNamespacesOnly: true,
+				AppNamespaces:     AppNamespaceSelector{Annotation: "analyzer/app=true"},
+			},
+			wantSelected: []string{"app"},
+			wantExcluded: map[string]string{
+				"web":         `missing annotation "analyzer/app"`,
+				"kube-system": `missing annotation "analyzer/app"`,
+				"ops":         `annotation "analyzer/app" is "false", not "true"`,
+			},
+		},
+		{
+			name: "annotation key only",
+			filters: FiltersConfig{
+				AppNamespacesOnly: true,
+				AppNamespaces:     AppNamespaceSelector{Annotation: "analyzer/app"},
+			},
+			wantSelected: []string{"app", "ops"},
+			wantExcluded: map[string]string{
+				"web":         `missing annotation "analyzer/app"`,
+				"kube-system": `missing annotation "analyzer/app"`,
+			},
+		},
+		{
+			name: "include and exclude filters come first",
+			filters: FiltersConfig{
+				IncludeNamespaces: []string{"app", "web", "kube-system"},
+				ExcludeNamespaces: []string{"web"},
+				AppNamespacesOnly: true,
+				AppNamespaces:     AppNamespaceSelector{NamePattern: "^[a-z0-9]{3}$"},
+			},
+			wantSelected: []string{"app"},
+			wantExcluded: map[string]string{
+				"web":         "listed in filters.exclude_namespaces",
+				"kube-system": `name does not match "^[a-z0-9]{3}$"`,
+				"ops":         "not listed in filters.include_namespaces",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := newNamespaceSelector(tt.filters)
+			if err != nil {
+				t.Fatal(err)
+			}
+			selected, excluded := selector.selectNamespaces(namespaces)
+
+			if !reflect.DeepEqual(selected, tt.wantSelected) {
+				t.Errorf("selected = %v, want %v", selected, tt.wantSelected)
+			}
+			reasons := make(map[string]string)
+			for _, e := range excluded {
+				reasons[e.Namespace] = e.Reason
+			}
+			if !reflect.DeepEqual(reasons, tt.wantExcluded) {
+				t.Errorf("excluded = %v, want %v", reasons, tt.wantExcluded)
+			}
+		})
+	}
+}
+
+func TestNewNamespaceSelectorErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		app     AppNamespaceSelector
+		wantErr string
+	}{
+		{name: "invalid pattern", app: AppNamespaceSelector{NamePattern: "("}, wantErr: "name_pattern:"},
+		{name: "invalid label selector", app: AppNamespaceSelector{LabelSelector: "tier in (a"}, wantErr: "label_selector:"},
+		{name: "annotation without key", app: AppNamespaceSelector{Annotation: "=true"}, wantErr: "annotation:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newNamespaceSelector(FiltersConfig{AppNamespaces: tt.app})
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("newNamespaceSelector() error = %v, want prefix %q", err, tt.wantErr)
+			}
+		})
+	}
+}
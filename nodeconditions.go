@@ -0,0 +1,151 @@
+package main
+
+import (
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Node problems reported by analyzeNodeConditions.
+const (
+	nodeNotReady           = "NotReady"
+	nodeMemoryPressure     = "MemoryPressure"
+	nodeDiskPressure       = "DiskPressure"
+	nodePIDPressure        = "PIDPressure"
+	nodeNetworkUnavailable = "NetworkUnavailable"
+	nodeCordoned           = "Cordoned"
+)
+
+// nodeProblemTaints maps the taints the node lifecycle controller sets to the
+// problem they stand for.
+var nodeProblemTaints = map[string]string{
+	corev1.TaintNodeNotReady:           nodeNotReady,
+	corev1.TaintNodeUnreachable:        nodeNotReady,
+	corev1.TaintNodeMemoryPressure:     nodeMemoryPressure,
+	corev1.TaintNodeDiskPressure:       nodeDiskPressure,
+	corev1.TaintNodePIDPressure:        nodePIDPressure,
+	corev1.TaintNodeNetworkUnavailable: nodeNetworkUnavailable,
+	corev1.TaintNodeUnschedulable:      nodeCordoned,
+}
+
+// NodeConditionIssue is a node that is not ready, under pressure or cordoned.
+type NodeConditionIssue struct {
+	NodeName string     `json:"nodeName"`
+	Problem  string     `json:"problem"` // NotReady, MemoryPressure, DiskPressure, PIDPressure, NetworkUnavailable or Cordoned
+	Reason   string     `json:"reason"`
+	Message  string     `json:"message"`
+	Since    *time.Time `json:"since,omitempty"` // last transition of the condition; nil when only a taint was found
+	Taint    string     `json:"taint,omitempty"` // "key:effect" of the matching taint, if any
+}
+
+// analyzeNodeConditions reports node conditions and taints that keep pods
+// from being scheduled or put running pods at risk of eviction.
+func (a *Analyzer) analyzeNodeConditions(nodes []corev1.Node) []NodeConditionIssue {
+	issues := []NodeConditionIssue{}
+
+	for _, node := range nodes {
+		found := make(map[string]int) // problem -> index in issues
+
+		for _, cond := range node.Status.Conditions {
+			problem := ""
+			switch cond.Type {
+			case corev1.NodeReady:
+				if cond.Status != corev1.ConditionTrue {
+					problem = nodeNotReady
+				}
+			case corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure, corev1.NodeNetworkUnavailable:
+				if cond.Status == corev1.ConditionTrue {
+					problem = string(cond.Type)
+				}
+			}
+			if problem == "" {
+				continue
+			}
+
+			found[problem] = len(issues)
+			issue := NodeConditionIssue{
+				NodeName: node.Name,
+				Problem:  problem,
+				Reason:   cond.Reason,
+				Message:  cond.Message,
+			}
+			if !cond.LastTransitionTime.IsZero() {
+				since := cond.LastTransitionTime.Time
+				issue.Since = &since
+			}
+			issues = append(issues, issue)
+		}
+
+		if node.Spec.Unschedulable {
+			found[nodeCordoned] = len(issues)
+			issues = append(issues, NodeConditionIssue{
+				NodeName: node.Name,
+				Problem:  nodeCordoned,
+				Reason:   "Unschedulable",
+				Message:  "Node is cordoned; no new pods are scheduled on it",
+			})
+		}
+
+		for _, taint := range node.Spec.Taints {
+			problem, ok := nodeProblemTaints[taint.Key]
+			if !ok {
+				continue
+			}
+			desc := taint.Key + ":" + string(taint.Effect)
+			if i, ok := found[problem]; ok {
+				if issues[i].Taint == "" {
+					issues[i].Taint = desc
+				}
+				continue
+			}
+
+			// The taint outlived or preceded the condition, e.g. an unreachable node
+			found[problem] = len(issues)
+			issues = append(issues, NodeConditionIssue{
+				NodeName: node.Name,
+				Problem:  problem,
+				Reason:   "Tainted",
+				Message:  "Node carries the " + taint.Key + " taint",
+				Taint:    desc,
+			})
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Problem != issues[j].Problem {
+			return nodeProblemOrder(issues[i].Problem) < nodeProblemOrder(issues[j].Problem)
+		}
+		return issues[i].NodeName < issues[j].NodeName
+	})
+	return issues
+}
+
+// nodeProblemOrder sorts the most severe problems first.
+func nodeProblemOrder(problem string) int {
+	switch problem {
+	case nodeNotReady:
+		return 0
+	case nodeNetworkUnavailable:
+		return 1
+	case nodeMemoryPressure, nodeDiskPressure, nodePIDPressure:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// nodesWith returns the names of the nodes with any of the given problems.
+func nodesWith(issues []NodeConditionIssue, problems ...string) []string {
+	seen := make(map[string]bool)
+	var nodes []string
+	for _, issue := range issues {
+		for _, p := range problems {
+			if issue.Problem == p && !seen[issue.NodeName] {
+				seen[issue.NodeName] = true
+				nodes = append(nodes, issue.NodeName)
+			}
+		}
+	}
+	return nodes
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAnalyzeNodeConditions(t *testing.T) {
+	since := metav1.NewTime(time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC))
+	condition := func(kind corev1.NodeConditionType, status corev1.ConditionStatus, reason string) corev1.NodeCondition {
+		return corev1.NodeCondition{Type: kind, Status: status, Reason: reason, LastTransitionTime: since}
+	}
+
+	nodes := []corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "healthy"},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				condition(corev1.NodeReady, corev1.ConditionTrue, "KubeletReady"),
+				condition(corev1.NodeMemoryPressure, corev1.ConditionFalse, "KubeletHasSufficientMemory"),
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "pressured"},
+			Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+				{Key: corev1.TaintNodeMemoryPressure, Effect: corev1.TaintEffectNoSchedule},
+			}},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				condition(corev1.NodeReady, corev1.ConditionTrue, "KubeletReady"),
+				condition(corev1.NodeMemoryPressure, corev1.ConditionTrue, "KubeletHasInsufficientMemory"),
+				condition(corev1.NodeDiskPressure, corev1.ConditionTrue, "KubeletHasDiskPressure"),
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "down"},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				condition(corev1.NodeReady, corev1.ConditionUnknown, "NodeStatusUnknown"),
+			}},
+		},
+		{
+			// Unreachable by taint only, e.g. before the condition was updated
+			ObjectMeta: metav1.ObjectMeta{Name: "unreachable"},
+			Spec: corev1.NodeSpec{Taints: []corev1.Taint{
+				{Key: corev1.TaintNodeUnreachable, Effect: corev1.TaintEffectNoExecute},
+				{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "cordoned"},
+			Spec: corev1.NodeSpec{
+				Unschedulable: true,
+				Taints:        []corev1.Taint{{Key: corev1.TaintNodeUnschedulable, Effect: corev1.TaintEffectNoSchedule}},
+			},
+		},
+	}
+
+	type issue struct {
+		node, problem, reason, taint string
+		since                        bool
+	}
+	want := []issue{
+		{node: "down", problem: nodeNotReady, reason: "NodeStatusUnknown", since: true},
+		{node: "unreachable", problem: nodeNotReady, reason: "Tainted", taint: "node.kubernetes.io/unreachable:NoExecute"},
+		{node: "pressured", problem: nodeMemoryPressure, reason: "KubeletHasInsufficientMemory", taint: "node.kubernetes.io/memory-pressure:NoSchedule", since: true},
+		{node: "pressured", problem: nodeDiskPressure, reason: "KubeletHasDiskPressure", since: true},
+		{node: "cordoned", problem: nodeCordoned, reason: "Unschedulable", taint: "node.kubernetes.io/unschedulable:NoSchedule"},
+	}
+
+	a := NewAnalyzer(nil, nil, DefaultConfig())
+	var got []issue
+	for _, i := range a.analyzeNodeConditions(nodes) {
+		got = append(got, issue{node: i.NodeName, problem: i.Problem, reason: i.Reason, taint: i.Taint, since: i.Since != nil})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("analyzeNodeConditions() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if nodes := nodesWith(a.analyzeNodeConditions(nodes), nodeNotReady, nodeCordoned); !reflect.DeepEqual(nodes, []string{"down", "unreachable", "cordoned"}) {
+		t.Errorf("nodesWith() = %v", nodes)
+	}
+}
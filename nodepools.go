@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// nodePoolLabels are the labels managed Kubernetes services and Karpenter set
+// to name a node's pool, in the order they are checked.
+var nodePoolLabels = []string{
+	"agentpool",
+	"kubernetes.azure.com/agentpool",
+	"eks.amazonaws.com/nodegroup",
+	"cloud.google.com/gke-nodepool",
+	"karpenter.sh/nodepool",
+}
+
+// unlabeledPool groups nodes that carry none of the pool labels.
+const unlabeledPool = "(no pool label)"
+
+// NodePoolAnalysis is the requested and allocatable resources of a node pool
+// and how evenly they are spread across its nodes.
+type NodePoolAnalysis struct {
+	Pool               string            `json:"pool"`
+	Label              string            `json:"label"` // label the pool name was read from; empty for unlabeled nodes
+	Nodes              int               `json:"nodes"`
+	RequestedCPU       float64           `json:"requestedCPUCores"`
+	RequestedMemory    float64           `json:"requestedMemoryGiB"`
+	AllocatableCPU     float64           `json:"allocatableCPUCores"`
+	AllocatableMemory  float64           `json:"allocatableMemoryGiB"`
+	CPUPercent         float64           `json:"cpuPercent"`
+	MemoryPercent      float64           `json:"memoryPercent"`
+	LimitCPU           float64           `json:"limitCPUCores"`
+	LimitMemory        float64           `json:"limitMemoryGiB"`
+	CPUOvercommit      float64           `json:"cpuOvercommit"`    // limits / allocatable
+	MemoryOvercommit   float64           `json:"memoryOvercommit"` // limits / allocatable
+	MetricsNodes       int               `json:"metricsNodes"`     // nodes with metrics-server usage
+	UsedCPU            float64           `json:"usedCPUCores"`
+	UsedMemory         float64           `json:"usedMemoryGiB"`
+	CPUUsagePercent    float64           `json:"cpuUsagePercent"`    // usage / allocatable of the nodes with metrics
+	MemoryUsagePercent float64           `json:"memoryUsagePercent"` // usage / allocatable of the nodes with metrics
+	CPUSpread          UtilizationSpread `json:"cpuSpread"`
+	MemorySpread       UtilizationSpread `json:"memorySpread"`
+	Outliers           []NodeOutlier     `json:"outliers"`
+}
+
+// UtilizationSpread describes the requested percentage of nodes in a pool.
+type UtilizationSpread struct {
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stdDev"`
+}
+
+// NodeOutlier is a node whose requested percentage is far from its pool's mean.
+type NodeOutlier struct {
+	NodeName string  `json:"nodeName"`
+	Resource string  `json:"resource"` // "cpu" or "memory"
+	Percent  float64 `json:"percent"`
+	PoolMean float64 `json:"poolMean"`
+}
+
+// nodePool returns the pool of a node and the label it was read from. A
+// configured label key is checked before the well-known ones.
+func (a *Analyzer) nodePool(labels map[string]string) (pool, label string) {
+	keys := nodePoolLabels
+	if key := a.config.NodePools.LabelKey; key != "" {
+		keys = append([]string{key}, nodePoolLabels...)
+	}
+	for _, key := range keys {
+		if value := labels[key]; value != "" {
+			return value, key
+		}
+	}
+	return unlabeledPool, ""
+}
+
+// analyzeNodePools groups nodes by pool and finds nodes whose requested CPU or
+// memory differs from the pool mean by more than the node_pool_outlier threshold.
+func (a *Analyzer) analyzeNodePools(allocations []nodeAllocation) []NodePoolAnalysis {
+	pools := make(map[string]*NodePoolAnalysis)
+	members := make(map[string][]nodeAllocation)
+	metered := make(map[string][2]float64) // pool -> allocatable CPU and memory of nodes with metrics
+
+	for _, alloc := range allocations {
+		name, label := a.nodePool(alloc.node.Labels)
+		pool, ok := pools[name]
+		if !ok {
+			pool = &NodePoolAnalysis{Pool: name, Label: label, Outliers: []NodeOutlier{}}
+			pools[name] = pool
+		}
+		pool.Nodes++
+		pool.RequestedCPU += alloc.RequestedCPU
+		pool.RequestedMemory += alloc.RequestedMemory
+		pool.AllocatableCPU += alloc.AllocatableCPU
+		pool.AllocatableMemory += alloc.AllocatableMemory
+		pool.LimitCPU += alloc.limitCPU
+		pool.LimitMemory += alloc.limitMemory
+		members[name] = append(members[name], alloc)
+
+		if alloc.usage != nil {
+			usedCPU, usedMemory := alloc.usedResources()
+			pool.MetricsNodes++
+			pool.UsedCPU += usedCPU
+			pool.UsedMemory += usedMemory
+			m := metered[name]
+			metered[name] = [2]float64{m[0] + alloc.AllocatableCPU, m[1] + alloc.AllocatableMemory}
+		}
+	}
+
+	result := make([]NodePoolAnalysis, 0, len(pools))
+	for name, pool := range pools {
+		pool.CPUPercent = percentOf(pool.RequestedCPU, pool.AllocatableCPU)
+		pool.MemoryPercent = percentOf(pool.RequestedMemory, pool.AllocatableMemory)
+		pool.CPUOvercommit = percentOf(pool.LimitCPU, pool.AllocatableCPU) / 100
+		pool.MemoryOvercommit = percentOf(pool.LimitMemory, pool.AllocatableMemory) / 100
+		pool.CPUUsagePercent = percentOf(pool.UsedCPU, metered[name][0])
+		pool.MemoryUsagePercent = percentOf(pool.UsedMemory, metered[name][1])
+
+		nodes := members[name]
+		pool.CPUSpread = spreadOf(nodes, func(n nodeAllocation) float64 { return n.CPUPercent })
+		pool.MemorySpread = spreadOf(nodes, func(n nodeAllocation) float64 { return n.MemoryPercent })
+
+		// A single node cannot be unbalanced against itself
+		if len(nodes) > 1 {
+			outlier := a.config.Thresholds.NodePoolOutlier
+			for _, n := range nodes {
+				if math.Abs(n.CPUPercent-pool.CPUSpread.Mean) > outlier {
+					pool.Outliers = append(pool.Outliers, NodeOutlier{NodeName: n.NodeName, Resource: "cpu", Percent: n.CPUPercent, PoolMean: pool.CPUSpread.Mean})
+				}
+				if math.Abs(n.MemoryPercent-pool.MemorySpread.Mean) > outlier {
+					pool.Outliers = append(pool.Outliers, NodeOutlier{NodeName: n.NodeName, Resource: "memory", Percent: n.MemoryPercent, PoolMean: pool.MemorySpread.Mean})
+				}
+			}
+		}
+
+		result = append(result, *pool)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Pool < result[j].Pool })
+	return result
+}
+
+func spreadOf(nodes []nodeAllocation, value func(nodeAllocation) float64) UtilizationSpread {
+	if len(nodes) == 0 {
+		return UtilizationSpread{}
+	}
+
+	spread := UtilizationSpread{Min: math.Inf(1), Max: math.Inf(-1)}
+	var sum float64
+	for _, n := range nodes {
+		v := value(n)
+		spread.Min = math.Min(spread.Min, v)
+		spread.Max = math.Max(spread.Max, v)
+		sum += v
+	}
+	spread.Mean = sum / float64(len(nodes))
+
+	var variance float64
+	for _, n := range nodes {
+		d := value(n) - spread.Mean
+		variance += d * d
+	}
+	spread.StdDev = math.Sqrt(variance / float64(len(nodes)))
+	return spread
+}
+
+// unbalancedPools returns the pools with outlier nodes.
+func unbalancedPools(pools []NodePoolAnalysis) []NodePoolAnalysis {
+	var unbalanced []NodePoolAnalysis
+	for _, pool := range pools {
+		if len(pool.Outliers) > 0 {
+			unbalanced = append(unbalanced, pool)
+		}
+	}
+	return unbalanced
+}
+
+// String describes an outlier, e.g. "node-1 cpu 92.0% (pool mean 41.5%)".
+func (o NodeOutlier) String() string {
+	return fmt.Sprintf("%s %s %.1f%% (pool mean %.1f%%)", o.NodeName, o.Resource, o.Percent, o.PoolMean)
+}
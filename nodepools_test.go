@@ -0,0 +1,90 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// poolNode returns the allocation of a node with the given labels and
+// requested percentages of 10 cores and 10 GiB.
+func poolNode(name string, labels map[string]string, cpuPercent, memoryPercent float64) nodeAllocation {
+	return nodeAllocation{
+		NodeIssue: NodeIssue{
+			NodeName:          name,
+			RequestedCPU:      cpuPercent / 10,
+			RequestedMemory:   memoryPercent / 10,
+			AllocatableCPU:    10,
+			AllocatableMemory: 10,
+			CPUPercent:        cpuPercent,
+			MemoryPercent:     memoryPercent,
+		},
+		node: &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}},
+	}
+}
+
+func TestAnalyzeNodePools(t *testing.T) {
+	system := map[string]string{"agentpool": "system"}
+	user := map[string]string{"eks.amazonaws.com/nodegroup": "user"}
+
+	a := NewAnalyzer(nil, nil, DefaultConfig())
+	pools := a.analyzeNodePools([]nodeAllocation{
+		poolNode("s1", system, 30, 50),
+		poolNode("s2", system, 30, 50),
+		poolNode("s3", system, 90, 50),
+		poolNode("u1", user, 95, 95),
+		poolNode("x1", nil, 10, 10),
+	})
+
+	if len(pools) != 3 {
+		t.Fatalf("got %d pools, want 3: %+v", len(pools), pools)
+	}
+	unlabeled, systemPool, userPool := pools[0], pools[1], pools[2]
+
+	if unlabeled.Pool != unlabeledPool || unlabeled.Label != "" || unlabeled.Nodes != 1 {
+		t.Errorf("unlabeled pool = %+v", unlabeled)
+	}
+	if userPool.Pool != "user" || userPool.Label != "eks.amazonaws.com/nodegroup" {
+		t.Errorf("user pool = %q from %q, want user from eks.amazonaws.com/nodegroup", userPool.Pool, userPool.Label)
+	}
+	if len(userPool.Outliers) != 0 {
+		t.Errorf("a single-node pool has outliers: %v", userPool.Outliers)
+	}
+
+	if systemPool.Pool != "system" || systemPool.Nodes != 3 {
+		t.Fatalf("system pool = %+v", systemPool)
+	}
+	if math.Abs(systemPool.CPUPercent-50) > 1e-9 {
+		t.Errorf("system pool CPU = %.2f%%, want 50%%", systemPool.CPUPercent)
+	}
+	spread := systemPool.CPUSpread
+	wantSpread := UtilizationSpread{Min: 30, Max: 90, Mean: 50, StdDev: math.Sqrt(800)}
+	if math.Abs(spread.Min-wantSpread.Min)+math.Abs(spread.Max-wantSpread.Max)+
+		math.Abs(spread.Mean-wantSpread.Mean)+math.Abs(spread.StdDev-wantSpread.StdDev) > 1e-9 {
+		t.Errorf("system pool CPU spread = %+v, want %+v", spread, wantSpread)
+	}
+
+	// s1 and s2 are 20 points below the CPU mean and s3 40 above; memory is even
+	want := []NodeOutlier{{NodeName: "s3", Resource: "cpu", Percent: 90, PoolMean: spread.Mean}}
+	if !reflect.DeepEqual(systemPool.Outliers, want) {
+		t.Errorf("system pool outliers = %+v, want %+v", systemPool.Outliers, want)
+	}
+}
+
+func TestNodePoolLabelKey(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.NodePools.LabelKey = "example.com/pool"
+	a := NewAnalyzer(nil, nil, cfg)
+
+	pool, label := a.nodePool(map[string]string{"agentpool": "system", "example.com/pool": "batch"})
+	if pool != "batch" || label != "example.com/pool" {
+		t.Errorf("nodePool() = %q, %q, want the configured label first", pool, label)
+	}
+	pool, label = a.nodePool(map[string]string{"agentpool": "system"})
+	if pool != "system" || label != "agentpool" {
+		t.Errorf("nodePool() = %q, %q, want the well-known label as fallback", pool, label)
+	}
+}
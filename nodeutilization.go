@@ -0,0 +1,112 @@
+package main
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Node utilization assessments.
+const (
+	nodeHot          = "hot"           // actual usage is above the node threshold
+	nodeOverReserved = "over-reserved" // requests are above the node threshold but usage is not
+)
+
+// NodeUtilization compares a node's actual usage from metrics-server with its
+// allocatable resources and the requests and limits of its pods.
+type NodeUtilization struct {
+	NodeName          string  `json:"nodeName"`
+	Pool              string  `json:"pool"`
+	UsedCPU           float64 `json:"usedCPUCores"`
+	UsedMemory        float64 `json:"usedMemoryGiB"`
+	RequestedCPU      float64 `json:"requestedCPUCores"`
+	RequestedMemory   float64 `json:"requestedMemoryGiB"`
+	LimitCPU          float64 `json:"limitCPUCores"`
+	LimitMemory       float64 `json:"limitMemoryGiB"`
+	AllocatableCPU    float64 `json:"allocatableCPUCores"`
+	AllocatableMemory float64 `json:"allocatableMemoryGiB"`
+
+	CPUUsagePercent       float64 `json:"cpuUsagePercent"`       // usage / allocatable
+	MemoryUsagePercent    float64 `json:"memoryUsagePercent"`    // usage / allocatable
+	CPUUsageOfRequests    float64 `json:"cpuUsageOfRequests"`    // usage / requests, in percent
+	MemoryUsageOfRequests float64 `json:"memoryUsageOfRequests"` // usage / requests, in percent
+	CPUOvercommit         float64 `json:"cpuOvercommit"`         // limits / allocatable
+	MemoryOvercommit      float64 `json:"memoryOvercommit"`      // limits / allocatable
+
+	Assessment string `json:"assessment"` // "hot", "over-reserved" or empty
+}
+
+// analyzeNodeUtilization reports usage against requests and limits for every
+// node with metrics, hottest first. A node is hot when its actual usage is
+// above the node threshold, and over-reserved when only its requests are.
+func (a *Analyzer) analyzeNodeUtilization(allocations []nodeAllocation) []NodeUtilization {
+	thresholds := a.config.Thresholds
+	result := []NodeUtilization{}
+
+	for _, alloc := range allocations {
+		if alloc.usage == nil {
+			continue
+		}
+		pool, _ := a.nodePool(alloc.node.Labels)
+		usedCPU, usedMemory := alloc.usedResources()
+
+		u := NodeUtilization{
+			NodeName:              alloc.NodeName,
+			Pool:                  pool,
+			UsedCPU:               usedCPU,
+			UsedMemory:            usedMemory,
+			RequestedCPU:          alloc.RequestedCPU,
+			RequestedMemory:       alloc.RequestedMemory,
+			LimitCPU:              alloc.limitCPU,
+			LimitMemory:           alloc.limitMemory,
+			AllocatableCPU:        alloc.AllocatableCPU,
+			AllocatableMemory:     alloc.AllocatableMemory,
+			CPUUsagePercent:       percentOf(usedCPU, alloc.AllocatableCPU),
+			MemoryUsagePercent:    percentOf(usedMemory, alloc.AllocatableMemory),
+			CPUUsageOfRequests:    percentOf(usedCPU, alloc.RequestedCPU),
+			MemoryUsageOfRequests: percentOf(usedMemory, alloc.RequestedMemory),
+			CPUOvercommit:         percentOf(alloc.limitCPU, alloc.AllocatableCPU) / 100,
+			MemoryOvercommit:      percentOf(alloc.limitMemory, alloc.AllocatableMemory) / 100,
+		}
+
+		switch {
+		case u.CPUUsagePercent > thresholds.NodeCPUThreshold || u.MemoryUsagePercent > thresholds.NodeMemoryThreshold:
+			u.Assessment = nodeHot
+		case alloc.CPUPercent > thresholds.NodeCPUThreshold || alloc.MemoryPercent > thresholds.NodeMemoryThreshold:
+			u.Assessment = nodeOverReserved
+		}
+
+		result = append(result, u)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return max(result[i].CPUUsagePercent, result[i].MemoryUsagePercent) >
+			max(result[j].CPUUsagePercent, result[j].MemoryUsagePercent)
+	})
+	return result
+}
+
+// usedResources returns the node's actual CPU (cores) and memory (GiB) usage.
+func (n nodeAllocation) usedResources() (cpu, memory float64) {
+	if n.usage == nil {
+		return 0, 0
+	}
+	if q, err := resource.ParseQuantity(n.usage.CPUUsage); err == nil {
+		cpu = float64(q.MilliValue()) / 1000
+	}
+	if q, err := resource.ParseQuantity(n.usage.MemoryUsage); err == nil {
+		memory = float64(q.Value()) / (1024 * 1024 * 1024)
+	}
+	return cpu, memory
+}
+
+// nodesAssessed returns the names of the nodes with the given assessment.
+func nodesAssessed(utilization []NodeUtilization, assessment string) []string {
+	var nodes []string
+	for _, u := range utilization {
+		if u.Assessment == assessment {
+			nodes = append(nodes, u.NodeName)
+		}
+	}
+	return nodes
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"sort"
+)
+
+// Node OOM risk levels.
+const (
+	oomRiskHigh   = "high"   // limits let pods outgrow the node and it is already near the memory threshold
+	oomRiskMedium = "medium" // limits let pods outgrow the node
+	oomRiskLow    = "low"    // memory limits fit within allocatable memory
+)
+
+// NodeOOMRisk rates how likely a node is to run out of memory and have the
+// kernel kill containers, from how far its pods' memory limits exceed its
+// allocatable memory and how much memory is in use. A container without a
+// memory limit can use all of the node's memory, so it counts as a limit of
+// the node's allocatable memory.
+type NodeOOMRisk struct {
+	NodeName            string  `json:"nodeName"`
+	Pool                string  `json:"pool"`
+	LimitMemory         float64 `json:"limitMemoryGiB"` // summed over containers with a memory limit
+	AllocatableMemory   float64 `json:"allocatableMemoryGiB"`
+	MemoryOvercommit    float64 `json:"memoryOvercommit"`    // limits, unbounded containers included, / allocatable
+	UnboundedContainers int     `json:"unboundedContainers"` // running containers without a memory limit
+	MemoryPercent       float64 `json:"memoryPercent"`       // usage / allocatable, or requests / allocatable without metrics
+	MemorySource        string  `json:"memorySource"`        // "usage" or "requests"
+	OOMKills            int     `json:"oomKills"`            // OOMKilled events recorded on the node
+	Risk                string  `json:"risk"`                // "high", "medium" or "low"
+	Score               float64 `json:"score"`               // memoryOvercommit * memoryPercent / 100, for ranking
+}
+
+// Overcommitted reports whether the pods on the node may together use more
+// memory than it has.
+func (r NodeOOMRisk) Overcommitted() bool {
+	return r.MemoryOvercommit > 1
+}
+
+// analyzeNodeOOMRisk rates every node by OOM kill risk, riskiest first, and
+// ranks nodes of the same risk by how far their limits exceed the node scaled
+// by how much memory is already in use. It also counts the OOM events seen on each so clusters of kills on overcommitted
+// nodes stand out.
+func (a *Analyzer) analyzeNodeOOMRisk(allocations []nodeAllocation, oomEvents []OOMEvent) []NodeOOMRisk {
+	kills := make(map[string]int)
+	for _, event := range oomEvents {
+		if event.NodeName != "" {
+			kills[event.NodeName]++
+		}
+	}
+
+	result := make([]NodeOOMRisk, 0, len(allocations))
+	for _, alloc := range allocations {
+		pool, _ := a.nodePool(alloc.node.Labels)
+		r := NodeOOMRisk{
+			NodeName:            alloc.NodeName,
+			Pool:                pool,
+			LimitMemory:         alloc.limitMemory,
+			AllocatableMemory:   alloc.AllocatableMemory,
+			MemoryOvercommit:    percentOf(alloc.limitMemory+float64(alloc.unboundedMemory)*alloc.AllocatableMemory, alloc.AllocatableMemory) / 100,
+			UnboundedContainers: alloc.unboundedMemory,
+			MemoryPercent:       alloc.MemoryPercent,
+			MemorySource:        "requests",
+			OOMKills:            kills[alloc.NodeName],
+		}
+		if alloc.usage != nil {
+			_, usedMemory := alloc.usedResources()
+			r.MemoryPercent = percentOf(usedMemory, alloc.AllocatableMemory)
+			r.MemorySource = "usage"
+		}
+		r.Score = r.MemoryOvercommit * r.MemoryPercent / 100
+
+		switch {
+		case r.Overcommitted() && r.MemoryPercent > a.config.Thresholds.NodeMemoryThreshold:
+			r.Risk = oomRiskHigh
+		case r.Overcommitted():
+			r.Risk = oomRiskMedium
+		default:
+			r.Risk = oomRiskLow
+		}
+
+		result = append(result, r)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Risk != result[j].Risk {
+			return oomRiskOrder(result[i].Risk) < oomRiskOrder(result[j].Risk)
+		}
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		if result[i].MemoryOvercommit != result[j].MemoryOvercommit {
+			return result[i].MemoryOvercommit > result[j].MemoryOvercommit
+		}
+		return result[i].OOMKills > result[j].OOMKills
+	})
+	return result
+}
+
+func oomRiskOrder(risk string) int {
+	switch risk {
+	case oomRiskHigh:
+		return 0
+	case oomRiskMedium:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// oomKillsOnOvercommitted returns how many OOM kills were recorded on a known
+// node and how many of those were on an overcommitted node.
+func oomKillsOnOvercommitted(risks []NodeOOMRisk) (overcommitted, total int) {
+	for _, r := range risks {
+		total += r.OOMKills
+		if r.Overcommitted() {
+			overcommitted += r.OOMKills
+		}
+	}
+	return overcommitted, total
+}
+
+// atRiskNodes returns the nodes with a medium or high OOM risk or with OOM
+// kills, which are the ones worth showing.
+func atRiskNodes(risks []NodeOOMRisk) []NodeOOMRisk {
+	var nodes []NodeOOMRisk
+	for _, r := range risks {
+		if r.Risk != oomRiskLow || r.OOMKills > 0 {
+			nodes = append(nodes, r)
+		}
+	}
+	return nodes
+}
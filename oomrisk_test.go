@@ -0,0 +1,58 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAnalyzeNodeOOMRisk(t *testing.T) {
+	// riskNode returns a node with 10 GiB allocatable, limits in GiB, the
+	// number of containers without a memory limit and the memory requested.
+	riskNode := func(name string, limits float64, unbounded int, memoryPercent float64) nodeAllocation {
+		alloc := poolNode(name, nil, 10, memoryPercent)
+		alloc.limitMemory = limits
+		alloc.unboundedMemory = unbounded
+		return alloc
+	}
+
+	a := NewAnalyzer(nil, nil, DefaultConfig())
+	risks := a.analyzeNodeOOMRisk([]nodeAllocation{
+		riskNode("fits", 8, 0, 90),
+		riskNode("one-unbounded", 0, 1, 50),
+		riskNode("idle", 30, 0, 10),
+		riskNode("unbounded", 4, 1, 50),
+		riskNode("hot", 12, 0, 85),
+	}, []OOMEvent{{NodeName: "idle"}, {NodeName: "idle"}, {NodeName: "fits"}})
+
+	want := []struct {
+		name       string
+		risk       string
+		overcommit float64
+	}{
+		{"hot", oomRiskHigh, 1.2},
+		// A container without a limit counts as a limit of the whole node,
+		// and memory in use outweighs a higher overcommit
+		{"unbounded", oomRiskMedium, 1.4},
+		{"idle", oomRiskMedium, 3},
+		// Limits within the node are low risk however much memory is in use
+		{"fits", oomRiskLow, 0.8},
+		// A single unbounded container can fill the node but not overcommit it
+		{"one-unbounded", oomRiskLow, 1},
+	}
+	if len(risks) != len(want) {
+		t.Fatalf("got %d nodes, want %d: %+v", len(risks), len(want), risks)
+	}
+	for i, w := range want {
+		r := risks[i]
+		if r.NodeName != w.name || r.Risk != w.risk || math.Abs(r.MemoryOvercommit-w.overcommit) > 1e-9 {
+			t.Errorf("risks[%d] = %s %s %.2fx, want %s %s %.2fx", i, r.NodeName, r.Risk, r.MemoryOvercommit, w.name, w.risk, w.overcommit)
+		}
+		if r.Overcommitted() != (w.overcommit > 1) {
+			t.Errorf("%s Overcommitted() = %v at %.2fx", r.NodeName, r.Overcommitted(), r.MemoryOvercommit)
+		}
+	}
+
+	if overcommitted, total := oomKillsOnOvercommitted(risks); overcommitted != 2 || total != 3 {
+		t.Errorf("kills on overcommitted nodes = %d of %d, want 2 of 3", overcommitted, total)
+	}
+}
@@ -9,79 +9,160 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
-func GenerateReport(data *ClusterData, analysis *Analysis) string {
+func GenerateReport(data *ClusterData, analysis *Analysis, cfg *Config) string {
 	var sb strings.Builder
+	sections := cfg.ReportSections
 
 	// Header
 	sb.WriteString("# Kubernetes Cluster Analysis Report\n\n")
 	sb.WriteString(fmt.Sprintf("**Cluster:** `%s`\n\n", data.ClusterName))
 	sb.WriteString(fmt.Sprintf("**Generated:** %s\n\n", time.Now().Format(time.RFC3339)))
+	if len(data.Scope) > 0 {
+		sb.WriteString(fmt.Sprintf("**Scope:** namespaces `%s` (namespace-scoped mode; node analysis is skipped)\n\n", strings.Join(data.Scope, "`, `")))
+	}
+	if gaps := data.DataGaps(); len(gaps) > 0 {
+		sb.WriteString("> ⚠️ **Incomplete data:** the following sources could not be collected, so related sections may be empty or understated. See the Data Coverage appendix.\n>\n")
+		for _, e := range gaps {
+			sb.WriteString(fmt.Sprintf("> - `%s`: %s\n", e.Source, e.Error))
+		}
+		sb.WriteString("\n")
+	}
 	sb.WriteString("---\n\n")
 
+	// New findings lead when comparing with a baseline
+	if analysis.Baseline != nil {
+		sb.WriteString(generateBaselineSection(analysis.Baseline))
+	}
+
 	// Cluster Health Summary
-	sb.WriteString(generateHealthSection(data, analysis))
+	if sections.ClusterHealth {
+		sb.WriteString(generateHealthSection(data, analysis))
+	}
 
 	// Critical Issues
-	sb.WriteString(generateCriticalIssuesSection(analysis))
+	if sections.CriticalIssues {
+		sb.WriteString(generateCriticalIssuesSection(analysis))
+	}
 
 	// Resource Management
-	sb.WriteString(generateResourceManagementSection(analysis))
+	if sections.ResourceManagement {
+		sb.WriteString(generateResourceManagementSection(analysis, cfg))
+	}
 
 	// Node Analysis
-	sb.WriteString(generateNodeAnalysisSection(analysis))
+	if sections.NodeAnalysis {
+		sb.WriteString(generateNodeAnalysisSection(analysis))
+	}
 
 	// Pod Restarts Analysis
-	sb.WriteString(generatePodRestartsSection(analysis))
+	if sections.PodRestarts {
+		sb.WriteString(generatePodRestartsSection(analysis))
+	}
 
 	// Flux Events Analysis
-	sb.WriteString(generateFluxEventsSection(analysis))
+	if sections.FluxEvents {
+		sb.WriteString(generateFluxEventsSection(analysis))
+	}
 
 	// Non-Flux Events Analysis
-	sb.WriteString(generateNonFluxEventsSection(analysis))
+	if sections.WarningEvents {
+		sb.WriteString(generateNonFluxEventsSection(analysis))
+	}
 
 	// Velero Backups Analysis
-	sb.WriteString(generateVeleroBackupsSection(analysis))
+	if sections.VeleroBackups {
+		sb.WriteString(generateVeleroBackupsSection(analysis))
+	}
 
 	// RabbitMQ Stability
-	sb.WriteString(generateRabbitMQSection(analysis))
+	if sections.RabbitMQStability {
+		sb.WriteString(generateRabbitMQSection(analysis, cfg))
+	}
 
 	// Namespace Analysis
-	sb.WriteString(generateNamespaceAnalysisSection(analysis))
+	if sections.NamespaceAnalysis {
+		sb.WriteString(generateNamespaceAnalysisSection(analysis))
+	}
 
 	// AI Insights (if available)
-	if analysis.AIInsights != nil {
+	if sections.AIInsights && analysis.AIInsights != nil {
 		sb.WriteString(generateAIInsightsSection(analysis.AIInsights))
 	}
 
 	// Appendix
-	sb.WriteString(generateAppendix(data, analysis))
+	if sections.Appendix {
+		sb.WriteString(generateAppendix(data, analysis, cfg))
+	}
 
 	return sb.String()
 }
 
-func generateHealthSection(data *ClusterData, analysis *Analysis) string {
+// maxBaselineFindings caps the new and resolved findings listed in the
+// Markdown report; the JSON and YAML reports list them all.
+const maxBaselineFindings = 50
+
+// generateBaselineSection lists the findings that are new since the baseline
+// report and those resolved since.
+func generateBaselineSection(comparison *BaselineComparison) string {
 	var sb strings.Builder
 
-	sb.WriteString("## 1. Cluster Health Summary\n\n")
+	sb.WriteString("## Changes Since Baseline\n\n")
+	sb.WriteString(fmt.Sprintf("Compared with `%s`, collected %s.\n\n", comparison.File, comparison.CollectedAt.Format(time.RFC3339)))
+	sb.WriteString(fmt.Sprintf("**New**: %d | **Persisting**: %d | **Resolved**: %d\n\n",
+		len(comparison.New), comparison.Persisting, len(comparison.Resolved)))
+
+	writeFindings := func(title string, findings []Finding) {
+		sb.WriteString(fmt.Sprintf("### %s (%d)\n\n", title, len(findings)))
+		sb.WriteString("| Severity | Rule | Object | Container | Message |\n")
+		sb.WriteString("|----------|------|--------|-----------|---------|\n")
+		for i, f := range findings {
+			if i == maxBaselineFindings {
+				break
+			}
+			container := "-"
+			if f.Container != "" {
+				container = f.Container
+			}
+			sb.WriteString(fmt.Sprintf("| %s | `%s` | `%s` | %s | %s |\n",
+				f.Severity, f.RuleID, f.Object, container, strings.ReplaceAll(f.Message, "|", "\\|")))
+		}
+		sb.WriteString("\n")
+		if len(findings) > maxBaselineFindings {
+			sb.WriteString(fmt.Sprintf("*%d more not shown; the JSON and YAML reports list them all.*\n\n", len(findings)-maxBaselineFindings))
+		}
+	}
 
-	healthEmoji := "🟢"
-	if analysis.ClusterHealth == "critical" {
-		healthEmoji = "🔴"
-	} else if analysis.ClusterHealth == "degraded" {
-		healthEmoji = "🟡"
+	if len(comparison.New) == 0 {
+		sb.WriteString("✅ No new findings since the baseline.\n\n")
+	} else {
+		writeFindings("🆕 New Findings", comparison.New)
+	}
+	if len(comparison.Resolved) > 0 {
+		writeFindings("✅ Resolved Findings", comparison.Resolved)
 	}
 
-	sb.WriteString(fmt.Sprintf("%s **Overall Health**: %s\n\n", healthEmoji, strings.ToUpper(analysis.ClusterHealth)))
+	sb.WriteString("---\n\n")
+	return sb.String()
+}
+
+func generateHealthSection(data *ClusterData, analysis *Analysis) string {
+	var sb strings.Builder
+
+	sb.WriteString("## 1. Cluster Health Summary\n\n")
+
+	sb.WriteString(fmt.Sprintf("%s **Overall Health**: %s\n\n", healthIcon(analysis.Health.Status), analysis.Health))
+	sb.WriteString(generateHealthBreakdown(analysis.Health))
 
 	sb.WriteString("### Key Metrics\n\n")
 	sb.WriteString("| Metric | Value |\n")
 	sb.WriteString("|--------|-------|\n")
 	sb.WriteString(fmt.Sprintf("| Total Pods | %d |\n", len(data.Pods)))
 	sb.WriteString(fmt.Sprintf("| Total Nodes | %d |\n", len(data.Nodes)))
-	sb.WriteString(fmt.Sprintf("| Pods Missing Resources | %d |\n", len(analysis.ResourceGaps)))
+	sb.WriteString(fmt.Sprintf("| Workload Containers Missing Resources | %d |\n", len(analysis.ResourceGaps)))
 	sb.WriteString(fmt.Sprintf("| OOM Events (Recent) | %d |\n", len(analysis.OOMEvents)))
 	sb.WriteString(fmt.Sprintf("| Pods with Restarts (24h) | %d |\n", analysis.PodRestarts.TotalPods24h))
 	sb.WriteString(fmt.Sprintf("| Pods with Restarts (7d) | %d |\n", analysis.PodRestarts.TotalPods7d))
+	sb.WriteString(fmt.Sprintf("| Nodes Not Ready | %d |\n", len(nodesWith(analysis.NodeConditions, nodeNotReady))))
 	sb.WriteString(fmt.Sprintf("| Node Issues | %d |\n", len(analysis.NodeIssues)))
 	sb.WriteString(fmt.Sprintf("| Namespaces at Risk | %d |\n\n", countHighRiskNamespaces(analysis.NamespaceAnalysis)))
 
@@ -97,14 +178,36 @@ func generateHealthSection(data *ClusterData, analysis *Analysis) string {
 	return sb.String()
 }
 
+// generateHealthBreakdown shows what each category of findings deducts from
+// the health score.
+func generateHealthBreakdown(health HealthScore) string {
+	var sb strings.Builder
+
+	sb.WriteString("### Health Score Breakdown\n\n")
+	sb.WriteString("Each finding deducts points by severity (critical 25, high 10, medium 4, low 1) from its category, up to the category's weight. ")
+	sb.WriteString("The cluster is critical below 50, with any critical finding or with a node that is down, and degraded below 80 or with a node under pressure.\n\n")
+	sb.WriteString("| Category | Findings | Deducted | Weight |\n")
+	sb.WriteString("|----------|----------|----------|--------|\n")
+	for _, c := range health.Categories {
+		findings := "0"
+		if c.Findings > 0 {
+			findings = fmt.Sprintf("%d (%s)", c.Findings, severityCountSummary(c.Severity))
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %.0f | %.0f |\n", c.Category, findings, c.Deducted, c.Weight))
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
 func generateCriticalIssuesSection(analysis *Analysis) string {
 	var sb strings.Builder
 
 	sb.WriteString("## 2. Critical Issues (Top 5)\n\n")
+	sb.WriteString(generateFindingsSummary(analysis.Findings))
 
 	if len(analysis.CriticalIssues) == 0 {
 		sb.WriteString("✅ No critical issues detected.\n\n")
-		return sb.String()
 	}
 
 	for i, issue := range analysis.CriticalIssues {
@@ -133,10 +236,79 @@ func generateCriticalIssuesSection(analysis *Analysis) string {
 		sb.WriteString("\n---\n\n")
 	}
 
+	sb.WriteString(generateWaivers(analysis.SuppressedFindings, analysis.ExpiredWaivers))
+
+	return sb.String()
+}
+
+// generateWaivers lists the findings waivers accepted and the waivers that
+// expired, so exceptions stay visible.
+func generateWaivers(suppressed []SuppressedFinding, expired []Waiver) string {
+	var sb strings.Builder
+
+	if len(suppressed) > 0 {
+		sb.WriteString(fmt.Sprintf("### Suppressed Findings (%d)\n\n", len(suppressed)))
+		sb.WriteString("These findings were accepted by a waiver and are left out of the findings, the health score and the report sections.\n\n")
+		sb.WriteString("| Rule | Object | Container | Waived By | Reason | Owner | Expires |\n")
+		sb.WriteString("|------|--------|-----------|-----------|--------|-------|---------|\n")
+		for _, s := range suppressed {
+			container, reason, owner, expires := "-", "-", "-", "-"
+			if s.Container != "" {
+				container = s.Container
+			}
+			if s.Reason != "" {
+				reason = strings.ReplaceAll(s.Reason, "|", "\\|")
+			}
+			if s.Owner != "" {
+				owner = s.Owner
+			}
+			if s.Expires != "" {
+				expires = s.Expires
+			}
+			waivedBy := "waivers file"
+			if s.WaivedBy != "file" {
+				waivedBy = fmt.Sprintf("annotation on `%s`", s.WaivedBy)
+			}
+			sb.WriteString(fmt.Sprintf("| `%s` | `%s` | %s | %s | %s | %s | %s |\n",
+				s.RuleID, s.Object, container, waivedBy, reason, owner, expires))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(expired) > 0 {
+		sb.WriteString(fmt.Sprintf("### ⚠️ Expired Waivers (%d)\n\n", len(expired)))
+		sb.WriteString("These waivers are past their expiry date and no longer suppress findings. Renew them with a new date or fix the findings.\n\n")
+		sb.WriteString("| Rule | Scope | Reason | Owner | Expired | Findings |\n")
+		sb.WriteString("|------|-------|--------|-------|---------|----------|\n")
+		for _, w := range expired {
+			sb.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s | %s | %d |\n",
+				w.Rule, w.Scope(), strings.ReplaceAll(w.Reason, "|", "\\|"), w.Owner, w.Expires, w.Matches))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// generateFindingsSummary renders how many findings each rule produced. The
+// full list with evidence is in the JSON and YAML reports.
+func generateFindingsSummary(findings []Finding) string {
+	if len(findings) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("**Findings**: %d (%s)\n\n", len(findings), severitySummary(findings)))
+	sb.WriteString("| Rule | Severity | Findings | Title |\n")
+	sb.WriteString("|------|----------|----------|-------|\n")
+	for _, count := range countByRule(findings) {
+		sb.WriteString(fmt.Sprintf("| `%s` | %s | %d | %s |\n", count.Rule.ID, count.Rule.Severity, count.Findings, count.Rule.Title))
+	}
+	sb.WriteString("\n")
 	return sb.String()
 }
 
-func generateResourceManagementSection(analysis *Analysis) string {
+func generateResourceManagementSection(analysis *Analysis, cfg *Config) string {
 	var sb strings.Builder
 
 	sb.WriteString("## 3. Resource Management Analysis\n\n")
@@ -149,8 +321,12 @@ func generateResourceManagementSection(analysis *Analysis) string {
 		missingRequests := 0
 		missingLimits := 0
 		missingBoth := 0
+		initOrSidecar := 0
 
 		for _, gap := range analysis.ResourceGaps {
+			if gap.ContainerType == containerTypeInit || gap.ContainerType == containerTypeSidecar {
+				initOrSidecar++
+			}
 			if gap.MissingRequests && gap.MissingLimits {
 				missingBoth++
 			} else if gap.MissingRequests {
@@ -160,9 +336,29 @@ func generateResourceManagementSection(analysis *Analysis) string {
 			}
 		}
 
-		sb.WriteString(fmt.Sprintf("- **Missing Both**: %d containers\n", missingBoth))
-		sb.WriteString(fmt.Sprintf("- **Missing Requests Only**: %d containers\n", missingRequests))
-		sb.WriteString(fmt.Sprintf("- **Missing Limits Only**: %d containers\n\n", missingLimits))
+		sb.WriteString(fmt.Sprintf("- **Missing Both**: %d workload containers\n", missingBoth))
+		sb.WriteString(fmt.Sprintf("- **Missing Requests Only**: %d workload containers\n", missingRequests))
+		sb.WriteString(fmt.Sprintf("- **Missing Limits Only**: %d workload containers\n", missingLimits))
+		sb.WriteString(fmt.Sprintf("- **Init and Sidecar Containers**: %d of these are init or sidecar containers, which also count towards the pod's scheduled requests\n\n", initOrSidecar))
+
+		// Largest workloads first: fixing one manifest fixes every replica
+		gaps := append([]ResourceGap(nil), analysis.ResourceGaps...)
+		sort.SliceStable(gaps, func(i, j int) bool { return gaps[i].Replicas > gaps[j].Replicas })
+
+		sb.WriteString("### Workloads to Fix\n\n")
+		if len(gaps) > 20 {
+			sb.WriteString(fmt.Sprintf("Showing the 20 largest of %d workload containers:\n\n", len(gaps)))
+		}
+		sb.WriteString("| Namespace | Workload | Replicas | Container | Missing Requests | Missing Limits |\n")
+		sb.WriteString("|-----------|----------|----------|-----------|------------------|----------------|\n")
+		for i, gap := range gaps {
+			if i >= 20 {
+				break
+			}
+			sb.WriteString(fmt.Sprintf("| %s | `%s` | %d | %s | %s | %s |\n",
+				gap.Namespace, gap.Workload, gap.Replicas, containerLabel(gap.Container, gap.ContainerType), yesNo(gap.MissingRequests), yesNo(gap.MissingLimits)))
+		}
+		sb.WriteString("\n")
 
 		sb.WriteString("### Impact on Cluster Operations\n\n")
 		sb.WriteString("**Velero Backups**:\n")
@@ -185,7 +381,7 @@ func generateResourceManagementSection(analysis *Analysis) string {
 	if analysis.ShortLivedJobs.TotalJobs > 0 {
 		sb.WriteString("### Short-Lived Jobs Impact\n\n")
 		sb.WriteString(fmt.Sprintf("- **Total Jobs**: %d\n", analysis.ShortLivedJobs.TotalJobs))
-		sb.WriteString(fmt.Sprintf("- **Short Jobs (<2 min)**: %d\n", analysis.ShortLivedJobs.ShortJobs))
+		sb.WriteString(fmt.Sprintf("- **Short Jobs (<%g min)**: %d\n", cfg.Thresholds.ShortJobDuration, analysis.ShortLivedJobs.ShortJobs))
 
 		if analysis.ShortLivedJobs.ShortJobs > 0 {
 			percentage := float64(analysis.ShortLivedJobs.ShortJobs) / float64(analysis.ShortLivedJobs.TotalJobs) * 100
@@ -219,28 +415,189 @@ func generateResourceManagementSection(analysis *Analysis) string {
 	return sb.String()
 }
 
+// generateNodeConditions renders nodes that are not ready, under pressure or cordoned.
+func generateNodeConditions(conditions []NodeConditionIssue) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("### Node Conditions\n\n")
+	sb.WriteString("| Node | Problem | Since | Reason | Taint | Message |\n")
+	sb.WriteString("|------|---------|-------|--------|-------|---------|\n")
+	for _, c := range conditions {
+		since := "-"
+		if c.Since != nil {
+			since = c.Since.Format("2006-01-02 15:04")
+		}
+		taint := "-"
+		if c.Taint != "" {
+			taint = "`" + c.Taint + "`"
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s |\n",
+			c.NodeName, c.Problem, since, c.Reason, taint, strings.ReplaceAll(c.Message, "|", "\\|")))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// generateNodePools renders requested vs allocatable resources per node pool
+// and the nodes that are outliers within their pool.
+func generateNodePools(pools []NodePoolAnalysis) string {
+	if len(pools) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("### Node Pools\n\n")
+	sb.WriteString("| Pool | Nodes | CPU Requested | CPU Spread (min / max / stddev) | Memory Requested | Memory Spread (min / max / stddev) | Used (CPU / Memory) | Limits Overcommit (CPU / Memory) | Outliers |\n")
+	sb.WriteString("|------|-------|---------------|---------------------------------|------------------|------------------------------------|---------------------|----------------------------------|----------|\n")
+	for _, pool := range pools {
+		used := "-"
+		if pool.MetricsNodes > 0 {
+			used = fmt.Sprintf("%.1f%% / %.1f%%", pool.CPUUsagePercent, pool.MemoryUsagePercent)
+			if pool.MetricsNodes < pool.Nodes {
+				used += fmt.Sprintf(" (%d of %d nodes)", pool.MetricsNodes, pool.Nodes)
+			}
+		}
+		sb.WriteString(fmt.Sprintf("| `%s` | %d | %.2f / %.2f cores (%.1f%%) | %.1f%% / %.1f%% / %.1f | %.2f / %.2f GiB (%.1f%%) | %.1f%% / %.1f%% / %.1f | %s | %.2fx / %.2fx | %d |\n",
+			pool.Pool, pool.Nodes,
+			pool.RequestedCPU, pool.AllocatableCPU, pool.CPUPercent,
+			pool.CPUSpread.Min, pool.CPUSpread.Max, pool.CPUSpread.StdDev,
+			pool.RequestedMemory, pool.AllocatableMemory, pool.MemoryPercent,
+			pool.MemorySpread.Min, pool.MemorySpread.Max, pool.MemorySpread.StdDev,
+			used, pool.CPUOvercommit, pool.MemoryOvercommit,
+			len(pool.Outliers)))
+	}
+	sb.WriteString("\n")
+
+	if unbalanced := unbalancedPools(pools); len(unbalanced) > 0 {
+		sb.WriteString("**Outlier nodes** (requests far from their pool's average):\n\n")
+		for _, pool := range unbalanced {
+			for _, outlier := range pool.Outliers {
+				sb.WriteString(fmt.Sprintf("- `%s`: %s\n", pool.Pool, outlier))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// generateNodeUtilization renders actual node usage from metrics-server next
+// to requests and limits, so hot nodes can be told apart from over-reserved ones.
+func generateNodeUtilization(utilization []NodeUtilization) string {
+	if len(utilization) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("### Node Utilization\n\n")
+	sb.WriteString("Usage is the metrics-server sample at collection time. **Hot** nodes are busy; **over-reserved** nodes are full on requests but not in use, so requests can likely be lowered.\n\n")
+	sb.WriteString("| Node | Pool | CPU Used | CPU Used / Requested | CPU Limits Overcommit | Memory Used | Memory Used / Requested | Memory Limits Overcommit | Assessment |\n")
+	sb.WriteString("|------|------|----------|----------------------|-----------------------|-------------|-------------------------|--------------------------|------------|\n")
+	for _, u := range utilization {
+		assessment := "-"
+		if u.Assessment != "" {
+			assessment = "**" + u.Assessment + "**"
+		}
+		sb.WriteString(fmt.Sprintf("| %s | `%s` | %.2f cores (%.1f%%) | %.1f%% | %.2fx | %.2f GiB (%.1f%%) | %.1f%% | %.2fx | %s |\n",
+			u.NodeName, u.Pool,
+			u.UsedCPU, u.CPUUsagePercent, u.CPUUsageOfRequests, u.CPUOvercommit,
+			u.UsedMemory, u.MemoryUsagePercent, u.MemoryUsageOfRequests, u.MemoryOvercommit,
+			assessment))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
 func generateNodeAnalysisSection(analysis *Analysis) string {
 	var sb strings.Builder
 
 	sb.WriteString("## 4. Node Analysis\n\n")
 
+	sb.WriteString(generateNodeConditions(analysis.NodeConditions))
+	sb.WriteString(generateNodePools(analysis.NodePools))
+	sb.WriteString(generateNodeUtilization(analysis.NodeUtilization))
+
 	if len(analysis.NodeIssues) == 0 {
 		sb.WriteString("✅ All nodes have healthy resource allocation.\n\n")
-		return sb.String()
+	} else {
+		sb.WriteString(generateNodeIssues(analysis.NodeIssues))
 	}
 
-	sb.WriteString("### Nodes with High Resource Utilization\n\n")
-	sb.WriteString("| Node Name | Issue | CPU Requested | Memory Requested | CPU Allocatable | Memory Allocatable |\n")
-	sb.WriteString("|-----------|-------|---------------|------------------|-----------------|--------------------|\n")
+	sb.WriteString(generateNodeOOMRisk(analysis.NodeOOMRisk))
+
+	// OOM Events
+	if len(analysis.OOMEvents) > 0 {
+		sb.WriteString("### OOMKilled Events\n\n")
+		sb.WriteString(fmt.Sprintf("Found %d OOMKilled events in events and container termination states:\n\n", len(analysis.OOMEvents)))
+
+		sb.WriteString("| Timestamp | Namespace | Pod | Container | Node | Source | Exit Code | Memory Limit | Last Usage |\n")
+		sb.WriteString("|-----------|-----------|-----|-----------|------|--------|-----------|--------------|------------|\n")
+
+		for i, event := range analysis.OOMEvents {
+			if i >= 10 { // Show top 10
+				sb.WriteString(fmt.Sprintf("\n_... and %d more events_\n\n", len(analysis.OOMEvents)-10))
+				break
+			}
+			exitCode, limit, usage := "-", "-", "-"
+			if event.ExitCode != 0 {
+				exitCode = fmt.Sprintf("%d", event.ExitCode)
+			}
+			if event.MemoryLimit != "" {
+				limit = event.MemoryLimit
+			}
+			if event.MemoryUsage != "" {
+				usage = event.MemoryUsage
+			}
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s | %s | %s |\n",
+				event.Timestamp.Format("2006-01-02 15:04:05"),
+				event.Namespace, event.PodName, event.Container, event.NodeName,
+				event.Source, exitCode, limit, usage))
+		}
+		sb.WriteString("\n")
 
-	for _, issue := range analysis.NodeIssues {
-		sb.WriteString(fmt.Sprintf("| %s | %s | %.2f cores | %.2f GB | %.2f cores | %.2f GB |\n",
-			issue.NodeName, issue.Issue,
-			issue.RequestedCPU, issue.RequestedMemory,
+		sb.WriteString("**Action Required**:\n")
+		sb.WriteString("- Increase memory limits for affected pods\n")
+		sb.WriteString("- Investigate application memory leaks\n")
+		sb.WriteString("- Consider implementing memory profiling\n\n")
+	}
+
+	return sb.String()
+}
+
+// generateNodeIssues renders nodes with high requests, their top requesting
+// pods and balancing recommendations.
+func generateNodeIssues(nodeIssues []NodeIssue) string {
+	var sb strings.Builder
+
+	sb.WriteString("### Nodes with High Resource Utilization\n\n")
+	sb.WriteString("Requests are summed over scheduled pods that have not completed or failed.\n\n")
+	sb.WriteString("| Node Name | Issue | Pods | CPU Requested | Memory Requested | CPU Allocatable | Memory Allocatable |\n")
+	sb.WriteString("|-----------|-------|------|---------------|------------------|-----------------|--------------------|\n")
+
+	for _, issue := range nodeIssues {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %d | %.2f cores (%.1f%%) | %.2f GiB (%.1f%%) | %.2f cores | %.2f GiB |\n",
+			issue.NodeName, issue.Issue, issue.PodCount,
+			issue.RequestedCPU, issue.CPUPercent, issue.RequestedMemory, issue.MemoryPercent,
 			issue.AllocatableCPU, issue.AllocatableMemory))
 	}
 	sb.WriteString("\n")
 
+	sb.WriteString("### Top Requesting Pods\n\n")
+	for _, issue := range nodeIssues {
+		if len(issue.TopPods) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("**%s** — %s:\n\n", issue.NodeName, issue.Issue))
+		for _, pod := range issue.TopPods {
+			sb.WriteString(fmt.Sprintf("- `%s/%s`: %.2f cores, %.2f GiB\n",
+				pod.Namespace, pod.PodName, pod.RequestedCPU, pod.RequestedMemory))
+		}
+		sb.WriteString("\n")
+	}
+
 	sb.WriteString("### Recommendations\n\n")
 	sb.WriteString("1. **Node Pool Balancing**:\n")
 	sb.WriteString("   - Review node pool sizing and consider adding nodes\n")
@@ -261,31 +618,39 @@ func generateNodeAnalysisSection(analysis *Analysis) string {
 	sb.WriteString("   - Set appropriate resource requests to enable efficient packing\n")
 	sb.WriteString("   - Review and optimize large workloads that may be causing imbalance\n\n")
 
-	// OOM Events
-	if len(analysis.OOMEvents) > 0 {
-		sb.WriteString("### OOMKilled Events\n\n")
-		sb.WriteString(fmt.Sprintf("Found %d OOMKilled events:\n\n", len(analysis.OOMEvents)))
+	return sb.String()
+}
 
-		sb.WriteString("| Timestamp | Namespace | Pod | Container | Node |\n")
-		sb.WriteString("|-----------|-----------|-----|-----------|------|\n")
+// generateNodeOOMRisk renders the nodes most likely to run out of memory next
+// to the OOM kills recorded on them.
+func generateNodeOOMRisk(risks []NodeOOMRisk) string {
+	if len(risks) == 0 {
+		return ""
+	}
 
-		for i, event := range analysis.OOMEvents {
-			if i >= 10 { // Show top 10
-				sb.WriteString(fmt.Sprintf("\n_... and %d more events_\n\n", len(analysis.OOMEvents)-10))
-				break
-			}
-			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
-				event.Timestamp.Format("2006-01-02 15:04:05"),
-				event.Namespace, event.PodName, event.Container, event.NodeName))
-		}
-		sb.WriteString("\n")
+	var sb strings.Builder
+	sb.WriteString("### Node OOM Risk\n\n")
+	sb.WriteString("When the memory limits of a node's pods add up to more than its allocatable memory, counting a container without a memory limit as the whole node, pods can together exhaust the node and the kernel kills containers even though each stays within its limit.\n\n")
 
-		sb.WriteString("**Action Required**:\n")
-		sb.WriteString("- Increase memory limits for affected pods\n")
-		sb.WriteString("- Investigate application memory leaks\n")
-		sb.WriteString("- Consider implementing memory profiling\n\n")
+	if overcommitted, total := oomKillsOnOvercommitted(risks); total > 0 {
+		sb.WriteString(fmt.Sprintf("**%d of %d** OOM kills with a known node happened on overcommitted nodes.\n\n", overcommitted, total))
+	}
+
+	nodes := atRiskNodes(risks)
+	if len(nodes) == 0 {
+		sb.WriteString("✅ Memory limits fit within allocatable memory on every node.\n\n")
+		return sb.String()
 	}
 
+	sb.WriteString("| Node | Pool | Risk | Memory Limits / Allocatable | Limits Overcommit | Containers Without Limit | Memory In Use | OOM Kills |\n")
+	sb.WriteString("|------|------|------|-----------------------------|-------------------|--------------------------|---------------|-----------|\n")
+	for _, r := range nodes {
+		sb.WriteString(fmt.Sprintf("| %s | `%s` | %s | %.2f / %.2f GiB | %.2fx | %d | %.1f%% (%s) | %d |\n",
+			r.NodeName, r.Pool, r.Risk,
+			r.LimitMemory, r.AllocatableMemory, r.MemoryOvercommit, r.UnboundedContainers,
+			r.MemoryPercent, r.MemorySource, r.OOMKills))
+	}
+	sb.WriteString("\n")
 	return sb.String()
 }
 
@@ -689,8 +1054,9 @@ func generateVeleroBackupsSection(analysis *Analysis) string {
 	return sb.String()
 }
 
-func generateRabbitMQSection(analysis *Analysis) string {
+func generateRabbitMQSection(analysis *Analysis, cfg *Config) string {
 	var sb strings.Builder
+	recommended := cfg.RabbitMQ.RecommendedResources
 
 	sb.WriteString("## 9. RabbitMQ Stability Analysis\n\n")
 
@@ -718,7 +1084,7 @@ func generateRabbitMQSection(analysis *Analysis) string {
 	sb.WriteString("kind: PriorityClass\n")
 	sb.WriteString("metadata:\n")
 	sb.WriteString("  name: rabbitmq-critical\n")
-	sb.WriteString("value: 1000000  # Higher than system-cluster-critical (2000000000 reserved for system)\n")
+	sb.WriteString(fmt.Sprintf("value: %d  # Below system-cluster-critical (2000000000 reserved for system)\n", cfg.Thresholds.CriticalPriority))
 	sb.WriteString("globalDefault: false\n")
 	sb.WriteString("description: \"Priority class for RabbitMQ to prevent eviction\"\n")
 	sb.WriteString("```\n\n")
@@ -735,11 +1101,11 @@ func generateRabbitMQSection(analysis *Analysis) string {
 	sb.WriteString("  - name: rabbitmq\n")
 	sb.WriteString("    resources:\n")
 	sb.WriteString("      requests:\n")
-	sb.WriteString("        memory: \"2Gi\"    # Set based on your observed usage\n")
-	sb.WriteString("        cpu: \"1000m\"     # 1 full CPU core\n")
+	sb.WriteString(fmt.Sprintf("        memory: %q    # Set based on your observed usage\n", recommended.Requests.Memory))
+	sb.WriteString(fmt.Sprintf("        cpu: %q     # Guaranteed CPU\n", recommended.Requests.CPU))
 	sb.WriteString("      limits:\n")
-	sb.WriteString("        memory: \"4Gi\"    # Allow headroom for spikes\n")
-	sb.WriteString("        cpu: \"2000m\"     # Allow burst capacity\n")
+	sb.WriteString(fmt.Sprintf("        memory: %q    # Allow headroom for spikes\n", recommended.Limits.Memory))
+	sb.WriteString(fmt.Sprintf("        cpu: %q     # Allow burst capacity\n", recommended.Limits.CPU))
 	sb.WriteString("```\n\n")
 
 	sb.WriteString("#### 3. Add PodDisruptionBudget\n\n")
@@ -794,9 +1160,9 @@ func generatePodRestartsSection(analysis *Analysis) string {
 
 	// Summary
 	sb.WriteString("### Summary\n\n")
-	sb.WriteString(fmt.Sprintf("- **Last 24 Hours**: %d pods with restarts (%d total restarts)\n",
+	sb.WriteString(fmt.Sprintf("- **Last 24 Hours**: %d pods with restarts across %d workload containers\n",
 		total24h, len(analysis.PodRestarts.Last24Hours)))
-	sb.WriteString(fmt.Sprintf("- **Last 7 Days**: %d pods with restarts (%d total restarts)\n\n",
+	sb.WriteString(fmt.Sprintf("- **Last 7 Days**: %d pods with restarts across %d workload containers\n\n",
 		total7d, len(analysis.PodRestarts.Last7Days)))
 
 	// Last 24 Hours
@@ -804,16 +1170,16 @@ func generatePodRestartsSection(analysis *Analysis) string {
 		sb.WriteString("### Restarts in Last 24 Hours\n\n")
 
 		if len(analysis.PodRestarts.Last24Hours) > 20 {
-			sb.WriteString(fmt.Sprintf("Showing top 20 of %d containers with restarts:\n\n",
+			sb.WriteString(fmt.Sprintf("Showing top 20 of %d workload containers with restarts:\n\n",
 				len(analysis.PodRestarts.Last24Hours)))
 		}
 
-		sb.WriteString("| Namespace | Pod | Container | Restart Count | Last Restart | Reason |\n")
-		sb.WriteString("|-----------|-----|-----------|---------------|--------------|--------|\n")
+		sb.WriteString("| Namespace | Workload | Pods | Container | Restart Count | Last Restart | Reason |\n")
+		sb.WriteString("|-----------|----------|------|-----------|---------------|--------------|--------|\n")
 
 		for i, restart := range analysis.PodRestarts.Last24Hours {
 			if i >= 20 {
-				sb.WriteString(fmt.Sprintf("\n_... and %d more containers with restarts_\n\n",
+				sb.WriteString(fmt.Sprintf("\n_... and %d more workload containers with restarts_\n\n",
 					len(analysis.PodRestarts.Last24Hours)-20))
 				break
 			}
@@ -823,9 +1189,10 @@ func generatePodRestartsSection(analysis *Analysis) string {
 				timeStr = restart.LastRestartTime.Format("2006-01-02 15:04")
 			}
 
-			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %d | %s | %s |\n",
+			sb.WriteString(fmt.Sprintf("| %s | `%s` | %d | %s | %d | %s | %s |\n",
 				restart.Namespace,
-				restart.PodName,
+				restart.Workload,
+				restart.Pods,
 				restart.ContainerName,
 				restart.RestartCount,
 				timeStr,
@@ -838,32 +1205,19 @@ func generatePodRestartsSection(analysis *Analysis) string {
 	if len(analysis.PodRestarts.Last7Days) > len(analysis.PodRestarts.Last24Hours) {
 		sb.WriteString("### Additional Restarts in Last 7 Days (excluding above)\n\n")
 
-		// Create map of 24h restarts to exclude
-		recent := make(map[string]bool)
-		for _, r := range analysis.PodRestarts.Last24Hours {
-			recent[r.Namespace+"/"+r.PodName+"/"+r.ContainerName] = true
-		}
-
-		// Filter out recent ones
-		older := []PodRestart{}
-		for _, r := range analysis.PodRestarts.Last7Days {
-			key := r.Namespace + "/" + r.PodName + "/" + r.ContainerName
-			if !recent[key] {
-				older = append(older, r)
-			}
-		}
+		older := olderRestarts(analysis.PodRestarts.Last24Hours, analysis.PodRestarts.Last7Days)
 
 		if len(older) > 0 {
 			if len(older) > 20 {
-				sb.WriteString(fmt.Sprintf("Showing top 20 of %d containers:\n\n", len(older)))
+				sb.WriteString(fmt.Sprintf("Showing top 20 of %d workload containers:\n\n", len(older)))
 			}
 
-			sb.WriteString("| Namespace | Pod | Container | Restart Count | Last Restart | Reason |\n")
-			sb.WriteString("|-----------|-----|-----------|---------------|--------------|--------|\n")
+			sb.WriteString("| Namespace | Workload | Pods | Container | Restart Count | Last Restart | Reason |\n")
+			sb.WriteString("|-----------|----------|------|-----------|---------------|--------------|--------|\n")
 
 			for i, restart := range older {
 				if i >= 20 {
-					sb.WriteString(fmt.Sprintf("\n_... and %d more containers_\n\n", len(older)-20))
+					sb.WriteString(fmt.Sprintf("\n_... and %d more workload containers_\n\n", len(older)-20))
 					break
 				}
 
@@ -872,9 +1226,10 @@ func generatePodRestartsSection(analysis *Analysis) string {
 					timeStr = restart.LastRestartTime.Format("2006-01-02 15:04")
 				}
 
-				sb.WriteString(fmt.Sprintf("| %s | %s | %s | %d | %s | %s |\n",
+				sb.WriteString(fmt.Sprintf("| %s | `%s` | %d | %s | %d | %s | %s |\n",
 					restart.Namespace,
-					restart.PodName,
+					restart.Workload,
+					restart.Pods,
 					restart.ContainerName,
 					restart.RestartCount,
 					timeStr,
@@ -954,7 +1309,8 @@ func generateNamespaceAnalysisSection(analysis *Analysis) string {
 	sb.WriteString("## 10. Namespace-by-Namespace Analysis\n\n")
 
 	if len(analysis.NamespaceAnalysis) == 0 {
-		sb.WriteString("ℹ️ No application namespaces found (looking for 3-letter codes).\n\n")
+		sb.WriteString("ℹ️ No application namespaces found. Adjust `filters.app_namespaces` in the config to select them.\n\n")
+		sb.WriteString(generateExcludedNamespaces(analysis.ExcludedNamespaces))
 		return sb.String()
 	}
 
@@ -1009,6 +1365,8 @@ func generateNamespaceAnalysisSection(analysis *Analysis) string {
 		}
 	}
 
+	sb.WriteString(generateExcludedNamespaces(analysis.ExcludedNamespaces))
+
 	sb.WriteString("### Namespace-Level Recommendations\n\n")
 	sb.WriteString("1. **Implement LimitRange defaults**:\n")
 	sb.WriteString("```yaml\n")
@@ -1046,6 +1404,24 @@ func generateNamespaceAnalysisSection(analysis *Analysis) string {
 	return sb.String()
 }
 
+// generateExcludedNamespaces lists the namespaces left out of the risk analysis and why.
+func generateExcludedNamespaces(excluded []ExcludedNamespace) string {
+	if len(excluded) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("### Excluded Namespaces (%d)\n\n", len(excluded)))
+	sb.WriteString("These namespaces were not part of the risk analysis:\n\n")
+	sb.WriteString("| Namespace | Reason |\n")
+	sb.WriteString("|-----------|--------|\n")
+	for _, ns := range excluded {
+		sb.WriteString(fmt.Sprintf("| `%s` | %s |\n", ns.Namespace, ns.Reason))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
 func generateNamespaceDetail(ns NamespaceAnalysis) string {
 	var sb strings.Builder
 
@@ -1057,14 +1433,14 @@ func generateNamespaceDetail(ns NamespaceAnalysis) string {
 	sb.WriteString(fmt.Sprintf("| Pods Missing Limits | %d |\n", ns.PodsWithoutLimits))
 	sb.WriteString(fmt.Sprintf("| Risk Level | %s |\n\n", strings.ToUpper(ns.RiskLevel)))
 
-	if len(ns.CriticalPods) > 0 {
-		sb.WriteString("**Critical Pods Missing Resources**:\n\n")
-		for i, pod := range ns.CriticalPods {
+	if len(ns.CriticalWorkloads) > 0 {
+		sb.WriteString("**Workloads Missing Resource Requests**:\n\n")
+		for i, workload := range ns.CriticalWorkloads {
 			if i >= 5 {
-				sb.WriteString(fmt.Sprintf("\n_... and %d more pods_\n\n", len(ns.CriticalPods)-5))
+				sb.WriteString(fmt.Sprintf("\n_... and %d more workloads_\n\n", len(ns.CriticalWorkloads)-5))
 				break
 			}
-			sb.WriteString(fmt.Sprintf("- `%s`\n", pod))
+			sb.WriteString(fmt.Sprintf("- `%s`\n", workload))
 		}
 		sb.WriteString("\n")
 	}
@@ -1111,13 +1487,13 @@ func generateAIInsightsSection(insights *AIInsights) string {
 	return sb.String()
 }
 
-func generateAppendix(data *ClusterData, analysis *Analysis) string {
+func generateAppendix(data *ClusterData, analysis *Analysis, cfg *Config) string {
 	var sb strings.Builder
 
 	sb.WriteString("## Appendix\n\n")
 
 	sb.WriteString("### A. Data Collection Summary\n\n")
-	sb.WriteString(fmt.Sprintf("- **Collection Time**: %s\n", time.Now().Format(time.RFC3339)))
+	sb.WriteString(fmt.Sprintf("- **Collection Time**: %s\n", data.CollectedAt.Format(time.RFC3339)))
 	sb.WriteString(fmt.Sprintf("- **Total Pods Analyzed**: %d\n", len(data.Pods)))
 	sb.WriteString(fmt.Sprintf("- **Total Nodes Analyzed**: %d\n", len(data.Nodes)))
 	sb.WriteString(fmt.Sprintf("- **Events Processed**: %d\n", len(data.Events)))
@@ -1129,7 +1505,9 @@ func generateAppendix(data *ClusterData, analysis *Analysis) string {
 		sb.WriteString("- **Metrics Available**: ⚠️ No (metrics-server not found or unavailable)\n\n")
 	}
 
-	sb.WriteString("### B. All Active Pods - Resource Configuration\n\n")
+	sb.WriteString(generateDataCoverage(data, analysis))
+
+	sb.WriteString("### C. All Active Pods - Resource Configuration\n\n")
 	sb.WriteString("Complete inventory of all running pods with their resource requests, limits, and current usage.\n")
 	if !metricsAvailable {
 		sb.WriteString("\n⚠️ **Note**: Current CPU/Memory usage shows 'N/A' because metrics-server is not available. Install metrics-server to see real-time usage data.\n")
@@ -1137,87 +1515,7 @@ func generateAppendix(data *ClusterData, analysis *Analysis) string {
 	sb.WriteString("\n")
 
 	// Collect pod resource information
-	podInfos := []PodResourceInfo{}
-	for _, pod := range data.Pods {
-		if pod.Status.Phase != corev1.PodRunning {
-			continue
-		}
-		for _, container := range pod.Spec.Containers {
-			podInfo := PodResourceInfo{
-				Namespace:     pod.Namespace,
-				PodName:       pod.Name,
-				ContainerName: container.Name,
-				Status:        string(pod.Status.Phase),
-			}
-
-			// Get configured requests and limits
-			if container.Resources.Requests != nil {
-				if cpu, ok := container.Resources.Requests[corev1.ResourceCPU]; ok {
-					podInfo.CPURequest = cpu.String()
-				} else {
-					podInfo.CPURequest = "Not Set"
-				}
-				if mem, ok := container.Resources.Requests[corev1.ResourceMemory]; ok {
-					podInfo.MemoryRequest = mem.String()
-				} else {
-					podInfo.MemoryRequest = "Not Set"
-				}
-			} else {
-				podInfo.CPURequest = "Not Set"
-				podInfo.MemoryRequest = "Not Set"
-			}
-
-			if container.Resources.Limits != nil {
-				if cpu, ok := container.Resources.Limits[corev1.ResourceCPU]; ok {
-					podInfo.CPULimit = cpu.String()
-				} else {
-					podInfo.CPULimit = "Not Set"
-				}
-				if mem, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
-					podInfo.MemoryLimit = mem.String()
-				} else {
-					podInfo.MemoryLimit = "Not Set"
-				}
-			} else {
-				podInfo.CPULimit = "Not Set"
-				podInfo.MemoryLimit = "Not Set"
-			}
-
-			// Get actual usage from metrics if available
-			podKey := pod.Namespace + "/" + pod.Name
-			if podMetrics, ok := data.PodMetrics[podKey]; ok {
-				if containerMetrics, ok := podMetrics.Containers[container.Name]; ok {
-					if containerMetrics.CPUUsage != "" {
-						podInfo.CurrentCPU = convertCPUToMillicores(containerMetrics.CPUUsage)
-					} else {
-						podInfo.CurrentCPU = "N/A"
-					}
-					if containerMetrics.MemoryUsage != "" {
-						podInfo.CurrentMemory = convertMemoryToMi(containerMetrics.MemoryUsage)
-					} else {
-						podInfo.CurrentMemory = "N/A"
-					}
-				} else {
-					podInfo.CurrentCPU = "N/A"
-					podInfo.CurrentMemory = "N/A"
-				}
-			} else {
-				podInfo.CurrentCPU = "N/A"
-				podInfo.CurrentMemory = "N/A"
-			}
-
-			podInfos = append(podInfos, podInfo)
-		}
-	} // Sort by namespace, then pod name, then container name
-	sort.Slice(podInfos, func(i, j int) bool {
-		if podInfos[i].Namespace != podInfos[j].Namespace {
-			return podInfos[i].Namespace < podInfos[j].Namespace
-		}
-		if podInfos[i].PodName != podInfos[j].PodName {
-			return podInfos[i].PodName < podInfos[j].PodName
-		}
-		return podInfos[i].ContainerName < podInfos[j].ContainerName
-	})
+	podInfos := buildPodInventory(data, cfg)
 
 	// Group by namespace
 	namespaceGroups := make(map[string][]PodResourceInfo)
@@ -1248,8 +1546,7 @@ func generateAppendix(data *ClusterData, analysis *Analysis) string {
 
 		for _, pod := range pods {
 			// Check if we have AI suggestion for this pod/container
-			suggestionKey := pod.PodName + "/" + pod.ContainerName
-			suggestion, hasSuggestion := nsSuggestions[suggestionKey]
+			suggestion, hasSuggestion := nsSuggestions[suggestionKey(pod.Workload, pod.ContainerName)]
 
 			cpuReq := pod.CPURequest
 			cpuLim := pod.CPULimit
@@ -1274,7 +1571,7 @@ func generateAppendix(data *ClusterData, analysis *Analysis) string {
 
 			sb.WriteString(fmt.Sprintf("| `%s` | `%s` | %s | %s | %s | %s | %s | %s | %s |\n",
 				pod.PodName,
-				pod.ContainerName,
+				containerLabel(pod.ContainerName, pod.ContainerType),
 				cpuReq,
 				cpuLim,
 				pod.CurrentCPU,
@@ -1292,13 +1589,17 @@ func generateAppendix(data *ClusterData, analysis *Analysis) string {
 		}
 	}
 
-	// Summary statistics
-	totalContainers := len(podInfos)
+	// Summary statistics, leaving out ephemeral containers
+	totalContainers := 0
 	containersWithoutRequests := 0
 	containersWithoutLimits := 0
 	containersFullyConfigured := 0
 
 	for _, pod := range podInfos {
+		if pod.ContainerType == containerTypeEphemeral {
+			continue
+		}
+		totalContainers++
 		hasRequests := pod.CPURequest != "Not Set" && pod.MemoryRequest != "Not Set"
 		hasLimits := pod.CPULimit != "Not Set" && pod.MemoryLimit != "Not Set"
 
@@ -1322,14 +1623,14 @@ func generateAppendix(data *ClusterData, analysis *Analysis) string {
 	sb.WriteString(fmt.Sprintf("- **Missing Limits**: %d (%.1f%%)\n\n",
 		containersWithoutLimits, float64(containersWithoutLimits)/float64(totalContainers)*100))
 
-	sb.WriteString("### C. Next Steps\n\n")
+	sb.WriteString("### D. Next Steps\n\n")
 	sb.WriteString("1. Review critical issues and prioritize based on business impact\n")
 	sb.WriteString("2. Implement resource requests/limits for high-risk namespaces first\n")
 	sb.WriteString("3. Set up monitoring for OOM events and resource utilization\n")
 	sb.WriteString("4. Establish policies (LimitRange, ResourceQuota) to prevent future issues\n")
 	sb.WriteString("5. Schedule follow-up analysis after implementing changes\n\n")
 
-	sb.WriteString("### D. Useful Commands\n\n")
+	sb.WriteString("### E. Useful Commands\n\n")
 	sb.WriteString("**Get pods without resource requests:**\n")
 	sb.WriteString("```bash\n")
 	sb.WriteString("kubectl get pods -A -o json | jq -r '.items[] | select(.spec.containers[].resources.requests == null) | \"\\(.metadata.namespace)/\\(.metadata.name)\"'\n")
@@ -1345,7 +1646,7 @@ func generateAppendix(data *ClusterData, analysis *Analysis) string {
 	sb.WriteString("kubectl get pod <pod-name> -n <namespace> -o jsonpath='{.spec.containers[*].resources}'\n")
 	sb.WriteString("```\n\n")
 
-	sb.WriteString("### E. Resources\n\n")
+	sb.WriteString("### F. Resources\n\n")
 	sb.WriteString("- [Kubernetes Best Practices - Resource Management](https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/)\n")
 	sb.WriteString("- [Pod Priority and Preemption](https://kubernetes.io/docs/concepts/scheduling-eviction/pod-priority-preemption/)\n")
 	sb.WriteString("- [Pod Disruption Budgets](https://kubernetes.io/docs/tasks/run-application/configure-pdb/)\n")
@@ -1356,23 +1657,172 @@ func generateAppendix(data *ClusterData, analysis *Analysis) string {
 	return sb.String()
 }
 
+// generateDataCoverage explains which sources were collected and which analyses
+// were skipped because their input was unavailable.
+func generateDataCoverage(data *ClusterData, analysis *Analysis) string {
+	var sb strings.Builder
+
+	sb.WriteString("### B. Data Coverage\n\n")
+	sb.WriteString("| Source | Status | Items | Detail |\n")
+	sb.WriteString("|--------|--------|-------|--------|\n")
+	for _, c := range data.Coverage() {
+		status := "✅ Collected"
+		switch c.Status {
+		case reasonForbidden:
+			status = "🔒 Forbidden"
+		case reasonNotInstalled:
+			status = "➖ Not installed"
+		case reasonError:
+			status = "❌ Error"
+		case reasonOutOfScope:
+			status = "⏭️ Out of scope"
+		}
+		detail := c.Detail
+		if detail == "" {
+			detail = "-"
+		}
+		sb.WriteString(fmt.Sprintf("| `%s` | %s | %d | %s |\n", c.Source, status, c.Items, strings.ReplaceAll(detail, "|", "\\|")))
+	}
+	sb.WriteString("\n")
+
+	if len(analysis.DisabledChecks) > 0 {
+		sb.WriteString(fmt.Sprintf("**Disabled checks** (not run by configuration): `%s`\n\n", strings.Join(analysis.DisabledChecks, "`, `")))
+	}
+
+	if len(analysis.SkippedAnalyses) == 0 {
+		sb.WriteString("All analyses ran with complete input data.\n\n")
+		return sb.String()
+	}
+
+	sb.WriteString("The following analyses were skipped because their input could not be collected; their report sections are empty rather than clean:\n\n")
+	sb.WriteString("| Check | Analysis | Missing Source | Reason |\n")
+	sb.WriteString("|-------|----------|----------------|--------|\n")
+	for _, s := range analysis.SkippedAnalyses {
+		sb.WriteString(fmt.Sprintf("| `%s` | %s | `%s` | %s |\n", s.Check, s.Analysis, s.Source, s.Reason))
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// buildPodInventory lists every running container with its configured resources
+// and current usage, sorted by namespace, pod and container.
+func buildPodInventory(data *ClusterData, cfg *Config) []PodResourceInfo {
+	podInfos := []PodResourceInfo{}
+	workloads := newWorkloadResolver(data)
+	for _, pod := range data.Pods {
+		if pod.Status.Phase != corev1.PodRunning || !cfg.Filters.Includes(pod.Namespace) {
+			continue
+		}
+		workload := workloads.resolve(&pod)
+		for _, container := range inventoryContainers(&pod) {
+			podInfo := PodResourceInfo{
+				Namespace:     pod.Namespace,
+				Workload:      workload,
+				Replicas:      1,
+				PodName:       pod.Name,
+				ContainerName: container.Name,
+				ContainerType: container.Type,
+				Status:        string(pod.Status.Phase),
+			}
+
+			// Get configured requests and limits
+			if container.Resources.Requests != nil {
+				if cpu, ok := container.Resources.Requests[corev1.ResourceCPU]; ok {
+					podInfo.CPURequest = cpu.String()
+				} else {
+					podInfo.CPURequest = "Not Set"
+				}
+				if mem, ok := container.Resources.Requests[corev1.ResourceMemory]; ok {
+					podInfo.MemoryRequest = mem.String()
+				} else {
+					podInfo.MemoryRequest = "Not Set"
+				}
+			} else {
+				podInfo.CPURequest = "Not Set"
+				podInfo.MemoryRequest = "Not Set"
+			}
+
+			if container.Resources.Limits != nil {
+				if cpu, ok := container.Resources.Limits[corev1.ResourceCPU]; ok {
+					podInfo.CPULimit = cpu.String()
+				} else {
+					podInfo.CPULimit = "Not Set"
+				}
+				if mem, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
+					podInfo.MemoryLimit = mem.String()
+				} else {
+					podInfo.MemoryLimit = "Not Set"
+				}
+			} else {
+				podInfo.CPULimit = "Not Set"
+				podInfo.MemoryLimit = "Not Set"
+			}
+
+			// Ephemeral containers cannot set resources, so nothing is missing
+			if container.Type == containerTypeEphemeral {
+				podInfo.CPURequest, podInfo.CPULimit = "N/A", "N/A"
+				podInfo.MemoryRequest, podInfo.MemoryLimit = "N/A", "N/A"
+			}
+
+			// Get actual usage from metrics if available
+			podKey := pod.Namespace + "/" + pod.Name
+			if podMetrics, ok := data.PodMetrics[podKey]; ok {
+				if containerMetrics, ok := podMetrics.Containers[container.Name]; ok {
+					if containerMetrics.CPUUsage != "" {
+						podInfo.CurrentCPU = convertCPUToMillicores(containerMetrics.CPUUsage)
+					} else {
+						podInfo.CurrentCPU = "N/A"
+					}
+					if containerMetrics.MemoryUsage != "" {
+						podInfo.CurrentMemory = convertMemoryToMi(containerMetrics.MemoryUsage)
+					} else {
+						podInfo.CurrentMemory = "N/A"
+					}
+				} else {
+					podInfo.CurrentCPU = "N/A"
+					podInfo.CurrentMemory = "N/A"
+				}
+			} else {
+				podInfo.CurrentCPU = "N/A"
+				podInfo.CurrentMemory = "N/A"
+			}
+
+			podInfos = append(podInfos, podInfo)
+		}
+	}
+
+	// Sort by namespace, then pod name, then container name
+	sort.Slice(podInfos, func(i, j int) bool {
+		if podInfos[i].Namespace != podInfos[j].Namespace {
+			return podInfos[i].Namespace < podInfos[j].Namespace
+		}
+		if podInfos[i].PodName != podInfos[j].PodName {
+			return podInfos[i].PodName < podInfos[j].PodName
+		}
+		return podInfos[i].ContainerName < podInfos[j].ContainerName
+	})
+
+	return podInfos
+}
+
 func generateActionItems(issue CriticalIssue) string {
 	var sb strings.Builder
 
-	switch issue.Title {
-	case "Missing Resource Requests and Limits":
+	switch issue.RuleID {
+	case ruleMissingRequests, ruleMissingLimits:
 		sb.WriteString("1. Audit all pods using: `kubectl get pods --all-namespaces -o json | jq '.items[] | select(.spec.containers[].resources.requests == null)'`\n")
 		sb.WriteString("2. Implement LimitRange in each namespace\n")
 		sb.WriteString("3. Update deployment manifests with appropriate resource values\n")
 		sb.WriteString("4. Use Vertical Pod Autoscaler to recommend resource values\n")
 
-	case "OOMKilled Events Detected":
+	case ruleOOMKilled:
 		sb.WriteString("1. Identify affected pods from the events list\n")
 		sb.WriteString("2. Increase memory limits by 50-100% initially\n")
 		sb.WriteString("3. Monitor memory usage patterns using metrics server or Prometheus\n")
 		sb.WriteString("4. Investigate potential memory leaks in applications\n")
 
-	case "High Node Resource Utilization":
+	case ruleNodeHighCPURequests, ruleNodeHighMemoryRequests:
 		sb.WriteString("1. Review cluster autoscaler configuration\n")
 		sb.WriteString("2. Add nodes to the cluster or scale up node pools\n")
 		sb.WriteString("3. Implement pod affinity/anti-affinity for better distribution\n")
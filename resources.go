@@ -0,0 +1,121 @@
+package main
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Container types, as shown for resource gaps and in the pod inventory.
+const (
+	containerTypeApp       = "container"
+	containerTypeInit      = "init"
+	containerTypeSidecar   = "sidecar" // init container with restartPolicy: Always
+	containerTypeEphemeral = "ephemeral"
+)
+
+// podContainer is a container of a pod with its type.
+type podContainer struct {
+	corev1.Container
+	Type string
+}
+
+// resourceContainers lists the containers of a pod that can set resources:
+// init containers, native sidecars and regular containers, in that order.
+// Ephemeral debug containers are left out because the API does not allow
+// resources on them and the scheduler does not account for them.
+func resourceContainers(pod *corev1.Pod) []podContainer {
+	containers := make([]podContainer, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers))
+	for _, c := range pod.Spec.InitContainers {
+		containerType := containerTypeInit
+		if isSidecar(c) {
+			containerType = containerTypeSidecar
+		}
+		containers = append(containers, podContainer{Container: c, Type: containerType})
+	}
+	for _, c := range pod.Spec.Containers {
+		containers = append(containers, podContainer{Container: c, Type: containerTypeApp})
+	}
+	return containers
+}
+
+// inventoryContainers lists every container of a pod including ephemeral
+// ones, which still use node resources while they run.
+func inventoryContainers(pod *corev1.Pod) []podContainer {
+	containers := resourceContainers(pod)
+	for _, c := range pod.Spec.EphemeralContainers {
+		containers = append(containers, podContainer{Container: corev1.Container(c.EphemeralContainerCommon), Type: containerTypeEphemeral})
+	}
+	return containers
+}
+
+func isSidecar(c corev1.Container) bool {
+	return c.RestartPolicy != nil && *c.RestartPolicy == corev1.ContainerRestartPolicyAlways
+}
+
+// containerLabel names a container for reports, marking init, sidecar and
+// ephemeral containers.
+func containerLabel(name, containerType string) string {
+	if containerType == "" || containerType == containerTypeApp {
+		return name
+	}
+	return name + " (" + containerType + ")"
+}
+
+// podRequests returns the effective requests of a pod the way the scheduler
+// computes them: regular containers and sidecars run together, each init
+// container runs alone alongside the sidecars started before it, the pod
+// needs the larger of the two, and pod overhead is added on top.
+func podRequests(pod *corev1.Pod) corev1.ResourceList {
+	return effectivePodResources(pod, func(r corev1.ResourceRequirements) corev1.ResourceList { return r.Requests })
+}
+
+// podLimits returns the effective limits of a pod, combined the same way as
+// requests. Containers without a limit add nothing.
+func podLimits(pod *corev1.Pod) corev1.ResourceList {
+	return effectivePodResources(pod, func(r corev1.ResourceRequirements) corev1.ResourceList { return r.Limits })
+}
+
+func effectivePodResources(pod *corev1.Pod, pick func(corev1.ResourceRequirements) corev1.ResourceList) corev1.ResourceList {
+	total := corev1.ResourceList{}
+	for _, c := range pod.Spec.Containers {
+		addResources(total, pick(c.Resources))
+	}
+
+	sidecars := corev1.ResourceList{}
+	initPeak := corev1.ResourceList{}
+	for _, c := range pod.Spec.InitContainers {
+		if isSidecar(c) {
+			addResources(total, pick(c.Resources))
+			addResources(sidecars, pick(c.Resources))
+			maxResources(initPeak, sidecars)
+			continue
+		}
+
+		running := corev1.ResourceList{}
+		addResources(running, pick(c.Resources))
+		addResources(running, sidecars)
+		maxResources(initPeak, running)
+	}
+	maxResources(total, initPeak)
+
+	addResources(total, pod.Spec.Overhead)
+	return total
+}
+
+func addResources(total, add corev1.ResourceList) {
+	for name, quantity := range add {
+		if current, ok := total[name]; ok {
+			current.Add(quantity)
+			total[name] = current
+		} else {
+			total[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+func maxResources(total, other corev1.ResourceList) {
+	for name, quantity := range other {
+		if current, ok := total[name]; !ok || quantity.Cmp(current) > 0 {
+			total[name] = quantity.DeepCopy()
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func cpu(value string) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(value)},
+		Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(value)},
+	}
+}
+
+func TestEffectivePodResources(t *testing.T) {
+	always := corev1.ContainerRestartPolicyAlways
+
+	tests := []struct {
+		name       string
+		spec       corev1.PodSpec
+		wantCPU    string // "" when the pod requests no CPU
+		wantLimits string
+	}{
+		{
+			name: "containers are summed",
+			spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "a", Resources: cpu("100m")},
+				{Name: "b", Resources: cpu("200m")},
+			}},
+			wantCPU:    "300m",
+			wantLimits: "300m",
+		},
+		{
+			name: "an init container larger than the containers",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "init", Resources: cpu("1")}},
+				Containers:     []corev1.Container{{Name: "app", Resources: cpu("200m")}},
+			},
+			wantCPU:    "1",
+			wantLimits: "1",
+		},
+		{
+			name: "a sidecar runs alongside the containers",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "proxy", RestartPolicy: &always, Resources: cpu("100m")}},
+				Containers:     []corev1.Container{{Name: "app", Resources: cpu("200m")}},
+			},
+			wantCPU:    "300m",
+			wantLimits: "300m",
+		},
+		{
+			name: "an init container after a sidecar runs alongside it",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{Name: "proxy", RestartPolicy: &always, Resources: cpu("100m")},
+					{Name: "migrate", Resources: cpu("1")},
+				},
+				Containers: []corev1.Container{{Name: "app", Resources: cpu("200m")}},
+			},
+			wantCPU:    "1100m",
+			wantLimits: "1100m",
+		},
+		{
+			name: "an init container before a sidecar runs alone",
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{
+					{Name: "migrate", Resources: cpu("1")},
+					{Name: "proxy", RestartPolicy: &always, Resources: cpu("100m")},
+				},
+				Containers: []corev1.Container{{Name: "app", Resources: cpu("200m")}},
+			},
+			wantCPU:    "1",
+			wantLimits: "1",
+		},
+		{
+			name: "pod overhead is added",
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "app", Resources: cpu("100m")}},
+				Overhead:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m")},
+			},
+			wantCPU:    "150m",
+			wantLimits: "150m",
+		},
+		{
+			name: "containers without limits add none",
+			spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "a", Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")}}},
+				{Name: "b", Resources: cpu("200m")},
+			}},
+			wantCPU:    "300m",
+			wantLimits: "200m",
+		},
+		{
+			name: "no resources",
+			spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+		},
+	}
+
+	check := func(t *testing.T, what string, got corev1.ResourceList, want string) {
+		t.Helper()
+		quantity, ok := got[corev1.ResourceCPU]
+		if want == "" {
+			if ok {
+				t.Errorf("%s CPU = %s, want none", what, quantity.String())
+			}
+			return
+		}
+		if !ok || quantity.Cmp(resource.MustParse(want)) != 0 {
+			t.Errorf("%s CPU = %s, want %s", what, quantity.String(), want)
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{Spec: tt.spec}
+			check(t, "requests", podRequests(pod), tt.wantCPU)
+			check(t, "limits", podLimits(pod), tt.wantLimits)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// reportServer keeps the most recent analysis and renders it on request.
+type reportServer struct {
+	cfg *Config
+
+	mu       sync.RWMutex
+	data     *ClusterData
+	analysis *Analysis
+	lastErr  error
+	lastRun  time.Time
+}
+
+func runServeCommand(cmd *command, args []string) error {
+	fs := cmd.newFlagSet()
+	settings := newSettingsFlags(fs).connection().ai().checks()
+	listen := fs.String("listen", "127.0.0.1:8080", "address to serve reports on; reports are unauthenticated, so binding to all interfaces (e.g. :8080) is your choice")
+	interval := fs.Duration("interval", time.Hour, "how often to re-analyze the cluster")
+	fs.Parse(args)
+
+	if *interval < time.Minute {
+		return fmt.Errorf("-interval must be at least 1m, got %s", *interval)
+	}
+
+	cfg, err := settings.load()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := &reportServer{cfg: cfg}
+	aiClient := newAIClientFromEnv(cfg, progress{})
+
+	go func() {
+		ticker := time.NewTicker(*interval)
+		defer ticker.Stop()
+		for {
+			server.refresh(ctx, aiClient)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", server.handleReport("html"))
+	mux.HandleFunc("/report.html", server.handleReport("html"))
+	mux.HandleFunc("/report.md", server.handleReport("markdown"))
+	mux.HandleFunc("/report.json", server.handleReport("json"))
+	mux.HandleFunc("/report.yaml", server.handleReport("yaml"))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/readyz", server.handleReady)
+
+	httpServer := &http.Server{
+		Addr:              *listen,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("🌐 Serving reports on %s (re-analyzing every %s)\n", *listen, *interval)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("Error serving reports: %w", err)
+	}
+	return nil
+}
+
+// refresh collects and analyzes the cluster once. A failed run keeps the
+// previous report available and records the error for /readyz.
+func (s *reportServer) refresh(ctx context.Context, aiClient *AIClient) {
+	out := progress{}
+	analyzer, data, err := loadClusterData(ctx, s.cfg, "", out)
+
+	var analysis *Analysis
+	if err == nil {
+		analysis = runAnalysis(ctx, s.cfg, analyzer, data, aiClient, out)
+	} else {
+		log.Printf("Warning: analysis failed: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastRun = time.Now()
+	s.lastErr = err
+	if err == nil {
+		s.data = data
+		s.analysis = analysis
+		out.Printf("✨ Report updated\n")
+	}
+}
+
+func (s *reportServer) handleReport(format string) http.HandlerFunc {
+	contentTypes := map[string]string{
+		"html":     "text/html; charset=utf-8",
+		"markdown": "text/markdown; charset=utf-8",
+		"json":     "application/json",
+		"yaml":     "application/yaml",
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if format == "html" && r.URL.Path != "/" && r.URL.Path != "/report.html" {
+			http.NotFound(w, r)
+			return
+		}
+
+		s.mu.RLock()
+		data, analysis := s.data, s.analysis
+		s.mu.RUnlock()
+
+		if analysis == nil {
+			http.Error(w, "no report available yet, see /readyz", http.StatusServiceUnavailable)
+			return
+		}
+
+		cfg := *s.cfg
+		cfg.Output.Format = format
+		report, err := RenderReport(data, analysis, &cfg)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", contentTypes[format])
+		w.Write(report)
+	}
+}
+
+func (s *reportServer) handleReady(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	switch {
+	case s.analysis == nil && s.lastErr != nil:
+		http.Error(w, fmt.Sprintf("analysis failed: %v", s.lastErr), http.StatusServiceUnavailable)
+	case s.analysis == nil:
+		http.Error(w, "analysis in progress", http.StatusServiceUnavailable)
+	case s.lastErr != nil:
+		fmt.Fprintf(w, "serving report from %s; last refresh at %s failed: %v\n",
+			s.data.CollectedAt.Format(time.RFC3339), s.lastRun.Format(time.RFC3339), s.lastErr)
+	default:
+		fmt.Fprintf(w, "serving report from %s\n", s.data.CollectedAt.Format(time.RFC3339))
+	}
+}
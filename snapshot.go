@@ -0,0 +1,104 @@
+package main
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// snapshotVersion is bumped whenever the snapshot layout changes incompatibly.
+const snapshotVersion = 1
+
+// Snapshot is the on-disk envelope for captured ClusterData. Files are
+// gzip-compressed JSON so they can be inspected with `zcat | jq`.
+type Snapshot struct {
+	Version    int          `json:"version"`
+	CapturedAt time.Time    `json:"capturedAt"`
+	Tool       string       `json:"tool"`
+	Data       *ClusterData `json:"data"`
+}
+
+// WriteSnapshot serializes the collected cluster data to a compressed archive.
+// The archive is written to a temporary file next to path and renamed into
+// place once complete, so a failed write never leaves a truncated snapshot or
+// clobbers an existing one.
+func WriteSnapshot(path string, data *ClusterData) (err error) {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating snapshot file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			file.Close()
+			os.Remove(file.Name())
+		}
+	}()
+
+	gz := gzip.NewWriter(file)
+	gz.Name = "cluster-data.json"
+	gz.ModTime = data.CollectedAt
+
+	snapshot := Snapshot{
+		Version:    snapshotVersion,
+		CapturedAt: data.CollectedAt,
+		Tool:       "k8s-resource-analyzer",
+		Data:       data,
+	}
+
+	if err := json.NewEncoder(gz).Encode(snapshot); err != nil {
+		return fmt.Errorf("error encoding snapshot: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("error compressing snapshot: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error writing snapshot file: %w", err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("error writing snapshot file: %w", err)
+	}
+	return nil
+}
+
+// ReadSnapshot loads cluster data previously written by WriteSnapshot.
+func ReadSnapshot(path string) (*ClusterData, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening snapshot file: %w", err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing snapshot: %w", err)
+	}
+	defer gz.Close()
+
+	var snapshot Snapshot
+	if err := json.NewDecoder(gz).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("error decoding snapshot: %w", err)
+	}
+
+	if snapshot.Version != snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d (expected %d)", snapshot.Version, snapshotVersion)
+	}
+	if snapshot.Data == nil {
+		return nil, fmt.Errorf("snapshot contains no cluster data")
+	}
+
+	data := snapshot.Data
+	if data.CollectedAt.IsZero() {
+		data.CollectedAt = snapshot.CapturedAt
+	}
+	if data.PodMetrics == nil {
+		data.PodMetrics = make(map[string]PodMetrics)
+	}
+	if data.NodeMetrics == nil {
+		data.NodeMetrics = make(map[string]NodeMetrics)
+	}
+
+	return data, nil
+}
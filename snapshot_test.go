@@ -0,0 +1,51 @@
+package main
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestWriteSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cluster.snapshot.gz")
+	collectedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	if err := WriteSnapshot(path, &ClusterData{ClusterName: "prod", CollectedAt: collectedAt}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ReadSnapshot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.ClusterName != "prod" || !data.CollectedAt.Equal(collectedAt) {
+		t.Errorf("read back %q collected at %s", data.ClusterName, data.CollectedAt)
+	}
+
+	// A failed write keeps the previous snapshot and leaves no partial file
+	unencodable := &ClusterData{
+		ClusterName:   "broken",
+		VeleroBackups: []unstructured.Unstructured{{Object: map[string]interface{}{"size": math.NaN()}}},
+	}
+	if err := WriteSnapshot(path, unencodable); err == nil {
+		t.Fatal("WriteSnapshot() of unencodable data succeeded, want an error")
+	}
+	if data, err := ReadSnapshot(path); err != nil || data.ClusterName != "prod" {
+		t.Errorf("after a failed write, snapshot = %v, %v, want the previous one", data, err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		t.Errorf("directory holds %v, want only the snapshot", names)
+	}
+}
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Annotations that waive findings on a pod, or on a workload through its pod
+// template. ReplicaSets carry the annotations of their Deployment.
+const (
+	ignoreAnnotation       = "analyzer.io/ignore"        // comma-separated "rule" or "rule:container" globs; "*" for every rule
+	ignoreReasonAnnotation = "analyzer.io/ignore-reason" // why the findings are accepted
+)
+
+// waiverDateLayout is the layout of waiver expiry dates.
+const waiverDateLayout = "2006-01-02"
+
+// Waiver accepts findings matching a rule and, optionally, an object and
+// container, until it expires. Every field that is set must match; rule,
+// namespace, kind, name and container are glob patterns, e.g. "resources/*".
+type Waiver struct {
+	Rule      string `json:"rule"`
+	Namespace string `json:"namespace,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Container string `json:"container,omitempty"`
+	Reason    string `json:"reason"`
+	Owner     string `json:"owner"`
+	Expires   string `json:"expires"`           // YYYY-MM-DD, the last day the waiver applies
+	Matches   int    `json:"matches,omitempty"` // for an expired waiver, the findings it would have matched in this run
+}
+
+// waiversFile is the layout of a central waivers file.
+type waiversFile struct {
+	Waivers []Waiver `json:"waivers"`
+}
+
+// SuppressedFinding is a finding that a waiver accepted. It is left out of
+// the findings and the health score but listed so the exception stays visible.
+type SuppressedFinding struct {
+	Finding
+	WaivedBy string `json:"waivedBy"` // "file", or the object whose annotation waived it, e.g. "Deployment/ns/name"
+	Reason   string `json:"reason,omitempty"`
+	Owner    string `json:"owner,omitempty"`
+	Expires  string `json:"expires,omitempty"`
+}
+
+// LoadWaivers reads and validates a central waivers file.
+func LoadWaivers(file string) ([]Waiver, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading waivers file %s: %w", file, err)
+	}
+
+	var parsed waiversFile
+	if err := yaml.UnmarshalStrict(content, &parsed); err != nil {
+		return nil, fmt.Errorf("error parsing waivers file %s: %w", file, err)
+	}
+
+	for i, w := range parsed.Waivers {
+		if err := w.validate(); err != nil {
+			return nil, fmt.Errorf("waivers file %s: waiver %d: %w", file, i+1, err)
+		}
+	}
+	return parsed.Waivers, nil
+}
+
+func (w Waiver) validate() error {
+	if w.Rule == "" {
+		return errors.New("rule is required")
+	}
+	if !strings.ContainsAny(w.Rule, "*?[") {
+		if _, ok := rules[w.Rule]; !ok {
+			return fmt.Errorf("unknown rule %q", w.Rule)
+		}
+	}
+	for field, pattern := range map[string]string{"rule": w.Rule, "namespace": w.Namespace, "kind": w.Kind, "name": w.Name, "container": w.Container} {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%s: invalid pattern %q", field, pattern)
+		}
+	}
+	if w.Reason == "" || w.Owner == "" {
+		return fmt.Errorf("rule %s: reason and owner are required", w.Rule)
+	}
+	if _, err := time.Parse(waiverDateLayout, w.Expires); err != nil {
+		return fmt.Errorf("rule %s: expires must be a date such as 2026-12-31, got %q", w.Rule, w.Expires)
+	}
+	return nil
+}
+
+// expired reports whether the waiver no longer applies at the given time.
+// It applies until the end of its expiry day.
+func (w Waiver) expired(now time.Time) bool {
+	expires, err := time.ParseInLocation(waiverDateLayout, w.Expires, now.Location())
+	return err != nil || !now.Before(expires.AddDate(0, 0, 1))
+}
+
+// Scope describes the objects the waiver covers, e.g.
+// "namespace=istio-system container=istio-proxy", or "all objects".
+func (w Waiver) Scope() string {
+	var parts []string
+	for _, p := range []struct{ field, value string }{
+		{"namespace", w.Namespace},
+		{"kind", w.Kind},
+		{"name", w.Name},
+		{"container", w.Container},
+	} {
+		if p.value != "" {
+			parts = append(parts, p.field+"="+p.value)
+		}
+	}
+	if len(parts) == 0 {
+		return "all objects"
+	}
+	return strings.Join(parts, " ")
+}
+
+// matches reports whether the waiver covers a finding.
+func (w Waiver) matches(f Finding) bool {
+	if !matchRule(w.Rule, f.RuleID) {
+		return false
+	}
+	for _, m := range []struct{ pattern, value string }{
+		{w.Namespace, f.Object.Namespace},
+		{w.Kind, f.Object.Kind},
+		{w.Name, f.Object.Name},
+		{w.Container, f.Container},
+	} {
+		if m.pattern == "" {
+			continue
+		}
+		if ok, _ := path.Match(m.pattern, m.value); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// matchRule reports whether a rule pattern covers a rule ID. Patterns are
+// path.Match globs, e.g. "resources/*"; a lone "*" covers every rule, even
+// though rule IDs contain a slash.
+func matchRule(pattern, ruleID string) bool {
+	if pattern == "*" {
+		return true
+	}
+	ok, _ := path.Match(pattern, ruleID)
+	return ok
+}
+
+// annotationWaiver is an ignore annotation entry and the object it is on.
+type annotationWaiver struct {
+	rule      string // glob pattern, as in the waivers file
+	container string // glob pattern; empty for every container
+	object    string
+	reason    string
+}
+
+// parseIgnoreAnnotation returns the entries of an object's ignore annotation.
+func parseIgnoreAnnotation(object string, annotations map[string]string) []annotationWaiver {
+	var waivers []annotationWaiver
+	for _, entry := range splitList(annotations[ignoreAnnotation]) {
+		rule, container, _ := strings.Cut(entry, ":")
+		waivers = append(waivers, annotationWaiver{rule: rule, container: container, object: object, reason: annotations[ignoreReasonAnnotation]})
+	}
+	return waivers
+}
+
+// annotationWaivers indexes the ignore annotations of pods and their owners by
+// the objects findings refer to: the pod itself and its workload.
+func annotationWaivers(data *ClusterData, workloads *workloadResolver) map[string][]annotationWaiver {
+	owners := make(map[string]map[string]string) // "Kind/namespace/name" -> annotations
+	for _, rs := range data.ReplicaSets {
+		owners["ReplicaSet/"+rs.Namespace+"/"+rs.Name] = rs.Annotations
+	}
+	for _, job := range data.Jobs {
+		owners["Job/"+job.Namespace+"/"+job.Name] = job.Annotations
+	}
+
+	index := make(map[string][]annotationWaiver)
+	seen := make(map[string]bool)
+	add := func(key string, waivers []annotationWaiver) {
+		for _, w := range waivers {
+			id := key + "|" + w.rule + "|" + w.container + "|" + w.object
+			if !seen[id] {
+				seen[id] = true
+				index[key] = append(index[key], w)
+			}
+		}
+	}
+
+	for i := range data.Pods {
+		pod := &data.Pods[i]
+		podRef := objectRef("Pod", pod.Namespace, pod.Name).String()
+		waivers := parseIgnoreAnnotation(podRef, pod.Annotations)
+		if owner := controllerKey(pod); owner != "" {
+			waivers = append(waivers, parseIgnoreAnnotation(owner, owners[owner])...)
+		}
+		if len(waivers) == 0 {
+			continue
+		}
+
+		workload := workloads.resolve(pod)
+		add(podRef, waivers)
+		add(objectRef(workload.Kind, pod.Namespace, workload.Name).String(), waivers)
+	}
+	return index
+}
+
+// controllerKey returns "Kind/namespace/name" of a pod's controller, or "".
+func controllerKey(pod *corev1.Pod) string {
+	for _, ref := range pod.OwnerReferences {
+		if ref.Controller != nil && *ref.Controller {
+			return ref.Kind + "/" + pod.Namespace + "/" + ref.Name
+		}
+	}
+	return ""
+}
+
+// applyWaivers splits findings into those that stand and those a waiver
+// accepts. Annotations are checked before the waivers file. Expired waivers
+// suppress nothing and are returned with the findings they would have
+// matched.
+func applyWaivers(findings []Finding, annotations map[string][]annotationWaiver, waivers []Waiver, now time.Time) (active []Finding, suppressed []SuppressedFinding, expired []Waiver) {
+	active = []Finding{}
+	expiredMatches := make([]int, len(waivers))
+
+	for _, f := range findings {
+		if s, ok := waivedByAnnotation(f, annotations[f.Object.String()]); ok {
+			suppressed = append(suppressed, s)
+			continue
+		}
+
+		waived := false
+		for i, w := range waivers {
+			if !w.matches(f) {
+				continue
+			}
+			if w.expired(now) {
+				expiredMatches[i]++
+				continue
+			}
+			suppressed = append(suppressed, SuppressedFinding{Finding: f, WaivedBy: "file", Reason: w.Reason, Owner: w.Owner, Expires: w.Expires})
+			waived = true
+			break
+		}
+		if !waived {
+			active = append(active, f)
+		}
+	}
+
+	for i, w := range waivers {
+		if w.expired(now) {
+			w.Matches = expiredMatches[i]
+			expired = append(expired, w)
+		}
+	}
+	sort.SliceStable(expired, func(i, j int) bool { return expired[i].Expires < expired[j].Expires })
+	return active, suppressed, expired
+}
+
+func waivedByAnnotation(f Finding, waivers []annotationWaiver) (SuppressedFinding, bool) {
+	for _, w := range waivers {
+		if !matchRule(w.rule, f.RuleID) {
+			continue
+		}
+		if w.container != "" {
+			if ok, _ := path.Match(w.container, f.Container); !ok {
+				continue
+			}
+		}
+		return SuppressedFinding{Finding: f, WaivedBy: w.object, Reason: w.reason}, true
+	}
+	return SuppressedFinding{}, false
+}
+
+// removeWaived drops the section entries whose findings were all waived, so
+// accepted problems do not reappear in the report sections, key metrics and
+// critical issues.
+func removeWaived(analysis *Analysis) {
+	waived := make(map[string]bool)
+	for _, s := range analysis.SuppressedFindings {
+		waived[s.ID] = true
+	}
+	if len(waived) == 0 {
+		return
+	}
+
+	analysis.ResourceGaps = withoutWaivedGaps(analysis.ResourceGaps, waived)
+	analysis.OOMEvents = withoutWaived(analysis.OOMEvents, waived, func(e OOMEvent) []Finding {
+		return oomFindings([]OOMEvent{e})
+	})
+	analysis.NodeIssues = withoutWaived(analysis.NodeIssues, waived, func(i NodeIssue) []Finding {
+		return nodeIssueFindings([]NodeIssue{i})
+	})
+	analysis.NodeConditions = withoutWaived(analysis.NodeConditions, waived, func(i NodeConditionIssue) []Finding {
+		return nodeConditionFindings([]NodeConditionIssue{i})
+	})
+	for i := range analysis.NodePools {
+		pool := &analysis.NodePools[i]
+		pool.Outliers = withoutWaived(pool.Outliers, waived, func(o NodeOutlier) []Finding {
+			return nodePoolFindings([]NodePoolAnalysis{{Pool: pool.Pool, Outliers: []NodeOutlier{o}}})
+		})
+	}
+
+	restarts := &analysis.PodRestarts
+	restartFinding := func(r PodRestart) []Finding {
+		return restartFindings(PodRestartAnalysis{Last7Days: []PodRestart{r}})
+	}
+	last24h, last7d := len(restarts.Last24Hours), len(restarts.Last7Days)
+	restarts.Last24Hours = withoutWaived(restarts.Last24Hours, waived, restartFinding)
+	restarts.Last7Days = withoutWaived(restarts.Last7Days, waived, restartFinding)
+	// The pod totals were counted before restarts were merged per workload;
+	// recount them from what is left, where something was waived
+	if len(restarts.Last24Hours) != last24h {
+		restarts.TotalPods24h = restartingPods(restarts.Last24Hours)
+	}
+	if len(restarts.Last7Days) != last7d {
+		restarts.TotalPods7d = restartingPods(restarts.Last7Days)
+	}
+}
+
+// withoutWaived keeps the entries with at least one finding that was not waived.
+func withoutWaived[T any](entries []T, waived map[string]bool, findings func(T) []Finding) []T {
+	if len(entries) == 0 {
+		return entries
+	}
+	kept := make([]T, 0, len(entries))
+	for _, entry := range entries {
+		for _, f := range findings(entry) {
+			if !waived[f.ID] {
+				kept = append(kept, entry)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// restartingPods estimates the pods with restarts from restarts merged per
+// workload container: the most pods any container of a workload restarted in.
+func restartingPods(restarts []PodRestart) int {
+	pods := make(map[string]int) // namespace/Kind/name -> pods
+	for _, r := range restarts {
+		key := r.Namespace + "/" + r.Workload.String()
+		pods[key] = max(pods[key], r.Pods)
+	}
+	total := 0
+	for _, n := range pods {
+		total += n
+	}
+	return total
+}
+
+// withoutWaivedGaps drops the parts of resource gaps whose findings were
+// waived, so accepted containers do not reappear in the resource sections
+// and critical issues.
+func withoutWaivedGaps(gaps []ResourceGap, waived map[string]bool) []ResourceGap {
+	kept := make([]ResourceGap, 0, len(gaps))
+	for _, gap := range gaps {
+		for _, f := range resourceGapFindings([]ResourceGap{gap}) {
+			switch {
+			case !waived[f.ID]:
+			case f.RuleID == ruleMissingRequests:
+				gap.MissingRequests = false
+			case f.RuleID == ruleMissingLimits:
+				gap.MissingLimits = false
+			}
+		}
+		if gap.MissingRequests || gap.MissingLimits {
+			kept = append(kept, gap)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApplyWaivers(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	gateway := objectRef("Deployment", "istio-system", "gateway")
+	finding := newFinding(ruleMissingLimits, gateway, "istio-proxy", "", nil)
+	finding.Container = "istio-proxy"
+
+	waiver := func(rule, namespace, container, expires string) Waiver {
+		return Waiver{Rule: rule, Namespace: namespace, Container: container, Reason: "mesh sidecar", Owner: "platform", Expires: expires}
+	}
+
+	tests := []struct {
+		name           string
+		annotations    map[string][]annotationWaiver
+		waivers        []Waiver
+		wantSuppressed string // WaivedBy of the suppressed finding, "" when it stands
+		wantExpired    int    // matches of the expired waiver, -1 when none expired
+	}{
+		{
+			name:        "no waivers",
+			wantExpired: -1,
+		},
+		{
+			name:           "rule and namespace globs",
+			waivers:        []Waiver{waiver("resources/*", "istio-*", "", "2026-12-31")},
+			wantSuppressed: "file",
+			wantExpired:    -1,
+		},
+		{
+			name:        "another container",
+			waivers:     []Waiver{waiver(ruleMissingLimits, "", "app", "2026-12-31")},
+			wantExpired: -1,
+		},
+		{
+			name:           "applies until the end of its expiry day",
+			waivers:        []Waiver{waiver(ruleMissingLimits, "", "istio-proxy", "2026-10-01")},
+			wantSuppressed: "file",
+			wantExpired:    -1,
+		},
+		{
+			name:        "expired",
+			waivers:     []Waiver{waiver(ruleMissingLimits, "", "istio-proxy", "2026-09-30")},
+			wantExpired: 1,
+		},
+		{
+			name:        "expired waiver that matches nothing",
+			waivers:     []Waiver{waiver(ruleMissingRequests, "", "", "2026-09-30")},
+			wantExpired: 0,
+		},
+		{
+			name: "annotation for every rule",
+			annotations: map[string][]annotationWaiver{
+				gateway.String(): {{rule: "*", object: gateway.String()}},
+			},
+			wantSuppressed: gateway.String(),
+			wantExpired:    -1,
+		},
+		{
+			name: "annotation before the waivers file",
+			annotations: map[string][]annotationWaiver{
+				gateway.String(): {{rule: ruleMissingLimits, container: "istio-proxy", object: gateway.String()}},
+			},
+			waivers:        []Waiver{waiver("*", "", "", "2026-12-31")},
+			wantSuppressed: gateway.String(),
+			wantExpired:    -1,
+		},
+		{
+			name: "annotation rule and container globs",
+			annotations: map[string][]annotationWaiver{
+				gateway.String(): {{rule: "resources/*", container: "istio-*", object: gateway.String()}},
+			},
+			wantSuppressed: gateway.String(),
+			wantExpired:    -1,
+		},
+		{
+			name:           "file waiver for every rule",
+			waivers:        []Waiver{waiver("*", "istio-system", "", "2026-12-31")},
+			wantSuppressed: "file",
+			wantExpired:    -1,
+		},
+		{
+			name: "annotation for another container",
+			annotations: map[string][]annotationWaiver{
+				gateway.String(): {{rule: ruleMissingLimits, container: "app", object: gateway.String()}},
+			},
+			wantExpired: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			active, suppressed, expired := applyWaivers([]Finding{finding}, tt.annotations, tt.waivers, now)
+
+			if tt.wantSuppressed == "" {
+				if len(active) != 1 || len(suppressed) != 0 {
+					t.Errorf("got %d active and %d suppressed findings, want the finding to stand", len(active), len(suppressed))
+				}
+			} else if len(active) != 0 || len(suppressed) != 1 || suppressed[0].WaivedBy != tt.wantSuppressed {
+				t.Errorf("got %d active and suppressed %+v, want the finding waived by %s", len(active), suppressed, tt.wantSuppressed)
+			}
+
+			switch {
+			case tt.wantExpired < 0 && len(expired) != 0:
+				t.Errorf("got expired waivers %+v, want none", expired)
+			case tt.wantExpired >= 0 && (len(expired) != 1 || expired[0].Matches != tt.wantExpired):
+				t.Errorf("got expired waivers %+v, want one matching %d findings", expired, tt.wantExpired)
+			}
+		})
+	}
+}
+
+func TestRemoveWaived(t *testing.T) {
+	killedAt := time.Date(2026, 10, 1, 11, 0, 0, 0, time.UTC)
+	web := Workload{Kind: "Deployment", Name: "web"}
+	api := Workload{Kind: "Deployment", Name: "api"}
+
+	analysis := &Analysis{
+		ResourceGaps: []ResourceGap{
+			{Namespace: "app", Workload: web, Container: "proxy", MissingRequests: true, MissingLimits: true},
+		},
+		OOMEvents: []OOMEvent{
+			{Namespace: "app", Workload: web, PodName: "web-1", Container: "main", Timestamp: killedAt},
+			{Namespace: "app", Workload: web, PodName: "web-2", Container: "main", Timestamp: killedAt},
+			{Namespace: "app", Workload: api, PodName: "api-1", Container: "main", Timestamp: killedAt},
+		},
+		PodRestarts: PodRestartAnalysis{
+			Last24Hours: []PodRestart{
+				{Namespace: "app", Workload: web, Pods: 2, ContainerName: "main", RestartCount: 4},
+				{Namespace: "app", Workload: api, Pods: 1, ContainerName: "main", RestartCount: 1},
+			},
+			Last7Days: []PodRestart{
+				{Namespace: "app", Workload: web, Pods: 2, ContainerName: "main", RestartCount: 4},
+				{Namespace: "app", Workload: api, Pods: 1, ContainerName: "main", RestartCount: 1},
+			},
+			TotalPods24h: 3,
+			TotalPods7d:  3,
+		},
+		NodeIssues: []NodeIssue{
+			{NodeName: "n1", Issue: "High CPU requests"},
+			{NodeName: "n2", Issue: "High memory requests"},
+		},
+		NodeConditions: []NodeConditionIssue{
+			{NodeName: "n1", Problem: nodeMemoryPressure},
+			{NodeName: "n2", Problem: nodeCordoned},
+		},
+	}
+
+	// Waive everything about web and node n1, except the missing limits of web's proxy
+	var waive []Finding
+	waive = append(waive, resourceGapFindings(analysis.ResourceGaps)[0])
+	waive = append(waive, oomFindings(analysis.OOMEvents[:1])...)
+	waive = append(waive, restartFindings(PodRestartAnalysis{Last7Days: analysis.PodRestarts.Last7Days[:1]})...)
+	waive = append(waive, nodeIssueFindings(analysis.NodeIssues[:1])...)
+	waive = append(waive, nodeConditionFindings(analysis.NodeConditions[:1])...)
+	for _, f := range waive {
+		analysis.SuppressedFindings = append(analysis.SuppressedFindings, SuppressedFinding{Finding: f, WaivedBy: "file"})
+	}
+
+	removeWaived(analysis)
+
+	if gaps := analysis.ResourceGaps; len(gaps) != 1 || gaps[0].MissingRequests || !gaps[0].MissingLimits {
+		t.Errorf("resource gaps = %+v, want only the missing limits of web's proxy", gaps)
+	}
+	if events := analysis.OOMEvents; len(events) != 1 || events[0].Workload != api {
+		t.Errorf("OOM events = %+v, want only api's", events)
+	}
+	restarts := analysis.PodRestarts
+	if len(restarts.Last24Hours) != 1 || len(restarts.Last7Days) != 1 || restarts.Last7Days[0].Workload != api {
+		t.Errorf("restarts = %+v, want only api's", restarts)
+	}
+	if restarts.TotalPods24h != 1 || restarts.TotalPods7d != 1 {
+		t.Errorf("pods with restarts = %d in 24h and %d in 7d, want 1", restarts.TotalPods24h, restarts.TotalPods7d)
+	}
+	if issues := analysis.NodeIssues; len(issues) != 1 || issues[0].NodeName != "n2" {
+		t.Errorf("node issues = %+v, want only n2's", issues)
+	}
+	if conditions := analysis.NodeConditions; len(conditions) != 1 || conditions[0].NodeName != "n2" {
+		t.Errorf("node conditions = %+v, want only n2's", conditions)
+	}
+}
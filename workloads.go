@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Workload is the top-level controller that owns a pod, e.g. the Deployment
+// behind a ReplicaSet. Pods without a controller are their own workload.
+type Workload struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// String returns "Kind/Name", the form used in reports and suggestion keys.
+func (w Workload) String() string {
+	return w.Kind + "/" + w.Name
+}
+
+// workloadKey identifies a container of a workload across its replicas.
+func workloadKey(namespace string, workload Workload, container string) string {
+	return namespace + "/" + workload.String() + "/" + container
+}
+
+// suggestionKey identifies a workload container within a namespace's AI suggestions.
+func suggestionKey(workload Workload, container string) string {
+	return workload.String() + "/" + container
+}
+
+// ownerMetadata keeps only what owner resolution and waivers need from an
+// object's metadata.
+func ownerMetadata(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	owner := metav1.ObjectMeta{
+		Name:            meta.Name,
+		Namespace:       meta.Namespace,
+		OwnerReferences: meta.OwnerReferences,
+	}
+	for _, key := range []string{ignoreAnnotation, ignoreReasonAnnotation} {
+		if value, ok := meta.Annotations[key]; ok {
+			if owner.Annotations == nil {
+				owner.Annotations = make(map[string]string)
+			}
+			owner.Annotations[key] = value
+		}
+	}
+	return owner
+}
+
+// workloadResolver resolves pods to workloads through owner references:
+// Pod→ReplicaSet→Deployment and Pod→Job→CronJob, and StatefulSets, DaemonSets
+// and other controllers directly.
+type workloadResolver struct {
+	owners map[string]*metav1.OwnerReference // "Kind/namespace/name" -> controller
+	pods   map[string]*corev1.Pod            // "namespace/name" -> pod
+}
+
+func newWorkloadResolver(data *ClusterData) *workloadResolver {
+	r := &workloadResolver{owners: make(map[string]*metav1.OwnerReference), pods: make(map[string]*corev1.Pod)}
+	for i := range data.Pods {
+		r.pods[data.Pods[i].Namespace+"/"+data.Pods[i].Name] = &data.Pods[i]
+	}
+	for _, rs := range data.ReplicaSets {
+		r.owners["ReplicaSet/"+rs.Namespace+"/"+rs.Name] = metav1.GetControllerOf(&rs)
+	}
+	for _, job := range data.Jobs {
+		r.owners["Job/"+job.Namespace+"/"+job.Name] = metav1.GetControllerOf(&job)
+	}
+	return r
+}
+
+func (r *workloadResolver) resolve(pod *corev1.Pod) Workload {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return Workload{Kind: "Pod", Name: pod.Name}
+	}
+	workload := Workload{Kind: ref.Kind, Name: ref.Name}
+
+	if ref.Kind != "ReplicaSet" && ref.Kind != "Job" {
+		return workload
+	}
+
+	owner, known := r.owners[ref.Kind+"/"+pod.Namespace+"/"+ref.Name]
+	if known {
+		if owner != nil {
+			return Workload{Kind: owner.Kind, Name: owner.Name}
+		}
+		return workload
+	}
+
+	// The ReplicaSet was not collected (e.g. not permitted or an older
+	// snapshot): Deployments name ReplicaSets "<deployment>-<pod-template-hash>"
+	if hash := pod.Labels["pod-template-hash"]; ref.Kind == "ReplicaSet" && hash != "" {
+		if name, ok := strings.CutSuffix(ref.Name, "-"+hash); ok {
+			return Workload{Kind: "Deployment", Name: name}
+		}
+	}
+	return workload
+}
+
+// resolveObject resolves an object known only by kind and name, e.g. from an
+// event, to its workload. Pods and ReplicaSets are replaced on every rollout,
+// so findings refer to their workload instead; a pod that no longer exists is
+// matched to a collected ReplicaSet or Job by its generated name. Other
+// objects are their own workload.
+func (r *workloadResolver) resolveObject(kind, namespace, name string) Workload {
+	switch kind {
+	case "Pod":
+		if pod, ok := r.pods[namespace+"/"+name]; ok {
+			return r.resolve(pod)
+		}
+		// Generated pod names are "<owner>-<random suffix>"
+		if i := strings.LastIndex(name, "-"); i > 0 {
+			for _, ownerKind := range []string{"ReplicaSet", "Job"} {
+				if _, known := r.owners[ownerKind+"/"+namespace+"/"+name[:i]]; known {
+					return r.resolveObject(ownerKind, namespace, name[:i])
+				}
+			}
+		}
+	case "ReplicaSet", "Job":
+		if owner := r.owners[kind+"/"+namespace+"/"+name]; owner != nil {
+			return Workload{Kind: owner.Kind, Name: owner.Name}
+		}
+	}
+	return Workload{Kind: kind, Name: name}
+}
+
+// summarizeWorkloads formats per-workload pod counts as "Kind/name (N pods)",
+// largest first.
+func summarizeWorkloads(counts map[Workload]int) []string {
+	workloads := make([]Workload, 0, len(counts))
+	for w := range counts {
+		workloads = append(workloads, w)
+	}
+	sort.Slice(workloads, func(i, j int) bool {
+		if counts[workloads[i]] != counts[workloads[j]] {
+			return counts[workloads[i]] > counts[workloads[j]]
+		}
+		return workloads[i].String() < workloads[j].String()
+	})
+
+	summary := make([]string, len(workloads))
+	for i, w := range workloads {
+		summary[i] = fmt.Sprintf("%s (%s)", w, pluralize(counts[w], "pod"))
+	}
+	return summary
+}
+
+// mergeRestarts merges restarts of the same workload container across its
+// pods, summing restart counts and keeping the pod, time and reason of the
+// latest restart.
+func mergeRestarts(restarts []PodRestart) []PodRestart {
+	merged := []PodRestart{}
+	index := make(map[string]int)
+	for _, r := range restarts {
+		key := workloadKey(r.Namespace, r.Workload, r.ContainerName)
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(merged)
+			merged = append(merged, r)
+			continue
+		}
+
+		m := &merged[i]
+		m.Pods += r.Pods
+		m.RestartCount += r.RestartCount
+		if r.LastRestartTime.After(m.LastRestartTime) {
+			m.PodName, m.LastRestartTime, m.Reason = r.PodName, r.LastRestartTime, r.Reason
+		}
+	}
+	return merged
+}
+
+// mergeResourceInfo reduces per-pod container resources to one entry per
+// workload container, counting replicas. Usage is taken from the replica
+// using the most memory, so suggestions cover the busiest pod.
+func mergeResourceInfo(infos []PodResourceInfo) []PodResourceInfo {
+	merged := []PodResourceInfo{}
+	index := make(map[string]int)
+	for _, info := range infos {
+		key := workloadKey(info.Namespace, info.Workload, info.ContainerName)
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(merged)
+			info.Replicas = 1
+			merged = append(merged, info)
+			continue
+		}
+
+		m := &merged[i]
+		m.Replicas++
+		if memoryBytes(info.CurrentMemory) > memoryBytes(m.CurrentMemory) {
+			m.PodName, m.CurrentCPU, m.CurrentMemory = info.PodName, info.CurrentCPU, info.CurrentMemory
+		}
+	}
+	return merged
+}
+
+// memoryBytes parses a memory quantity such as "512Mi", or returns -1 for "N/A".
+func memoryBytes(value string) int64 {
+	q, err := resource.ParseQuantity(value)
+	if err != nil {
+		return -1
+	}
+	return q.Value()
+}
+
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
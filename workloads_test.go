@@ -0,0 +1,87 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// controllerRef returns a controller owner reference to the named object.
+func controllerRef(kind, name string) []metav1.OwnerReference {
+	controller := true
+	return []metav1.OwnerReference{{Kind: kind, Name: name, Controller: &controller}}
+}
+
+func TestWorkloadResolver(t *testing.T) {
+	data := &ClusterData{
+		ReplicaSets: []appsv1.ReplicaSet{
+			{ObjectMeta: metav1.ObjectMeta{Name: "web-7d9f", Namespace: "app", OwnerReferences: controllerRef("Deployment", "web")}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "bare-rs", Namespace: "app"}},
+		},
+		Jobs: []batchv1.Job{
+			{ObjectMeta: metav1.ObjectMeta{Name: "backup-2901", Namespace: "app", OwnerReferences: controllerRef("CronJob", "backup")}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "app"}},
+		},
+	}
+	resolver := newWorkloadResolver(data)
+
+	tests := []struct {
+		name   string
+		owners []metav1.OwnerReference
+		labels map[string]string
+		want   Workload
+	}{
+		{name: "bare pod", want: Workload{Kind: "Pod", Name: "pod"}},
+		{name: "ReplicaSet to Deployment", owners: controllerRef("ReplicaSet", "web-7d9f"), want: Workload{Kind: "Deployment", Name: "web"}},
+		{name: "ReplicaSet without owner", owners: controllerRef("ReplicaSet", "bare-rs"), want: Workload{Kind: "ReplicaSet", Name: "bare-rs"}},
+		{name: "Job to CronJob", owners: controllerRef("Job", "backup-2901"), want: Workload{Kind: "CronJob", Name: "backup"}},
+		{name: "Job without owner", owners: controllerRef("Job", "migrate"), want: Workload{Kind: "Job", Name: "migrate"}},
+		{name: "StatefulSet", owners: controllerRef("StatefulSet", "rabbitmq"), want: Workload{Kind: "StatefulSet", Name: "rabbitmq"}},
+		{
+			name:   "uncollected ReplicaSet falls back to pod-template-hash",
+			owners: controllerRef("ReplicaSet", "api-5c6b8"),
+			labels: map[string]string{"pod-template-hash": "5c6b8"},
+			want:   Workload{Kind: "Deployment", Name: "api"},
+		},
+		{
+			name:   "uncollected ReplicaSet with a foreign hash",
+			owners: controllerRef("ReplicaSet", "api-5c6b8"),
+			labels: map[string]string{"pod-template-hash": "0000"},
+			want:   Workload{Kind: "ReplicaSet", Name: "api-5c6b8"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "app", Labels: tt.labels, OwnerReferences: tt.owners}}
+			if got := resolver.resolve(pod); got != tt.want {
+				t.Errorf("resolve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeRestarts(t *testing.T) {
+	web := Workload{Kind: "Deployment", Name: "web"}
+	earlier := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+
+	got := mergeRestarts([]PodRestart{
+		{Namespace: "app", Workload: web, Pods: 1, PodName: "web-a", ContainerName: "main", RestartCount: 2, LastRestartTime: earlier, Reason: "Error"},
+		{Namespace: "app", Workload: web, Pods: 1, PodName: "web-b", ContainerName: "main", RestartCount: 3, LastRestartTime: later, Reason: "OOMKilled"},
+		{Namespace: "app", Workload: web, Pods: 1, PodName: "web-a", ContainerName: "proxy", RestartCount: 1, LastRestartTime: earlier},
+	})
+
+	want := []PodRestart{
+		{Namespace: "app", Workload: web, Pods: 2, PodName: "web-b", ContainerName: "main", RestartCount: 5, LastRestartTime: later, Reason: "OOMKilled"},
+		{Namespace: "app", Workload: web, Pods: 1, PodName: "web-a", ContainerName: "proxy", RestartCount: 1, LastRestartTime: earlier},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeRestarts() = %+v, want %+v", got, want)
+	}
+}